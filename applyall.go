@@ -0,0 +1,236 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// ApplyResult holds the outcome of applying a single object via ApplyAll.
+type ApplyResult struct {
+	// Object is the object that was applied.
+	Object NamedObject
+
+	// Resource is the resolved resource of the object. It is empty if the
+	// object's kind could not be resolved.
+	Resource schema.GroupVersionResource
+
+	// Err is set if the object could not be applied.
+	Err error
+}
+
+// applyOrder defines the order in which kinds are applied by ApplyAll.
+// Kinds not in this list are applied last, in the order they were passed.
+var applyOrder = []string{
+	"Namespace",
+	"CustomResourceDefinition",
+	"PriorityClass",
+	"StorageClass",
+	"ServiceAccount",
+	"ClusterRole",
+	"ClusterRoleBinding",
+	"Role",
+	"RoleBinding",
+	"ResourceQuota",
+	"LimitRange",
+	"NetworkPolicy",
+	"ConfigMap",
+	"Secret",
+	"PersistentVolume",
+	"PersistentVolumeClaim",
+	"Service",
+	"DaemonSet",
+	"Pod",
+	"ReplicationController",
+	"ReplicaSet",
+	"Deployment",
+	"StatefulSet",
+	"Job",
+	"CronJob",
+	"HorizontalPodAutoscaler",
+	"PodDisruptionBudget",
+	"IngressClass",
+	"Ingress",
+}
+
+var (
+	// crdPollInterval is the interval used to check if applied CRDs have
+	// become established.
+	crdPollInterval = 500 * time.Millisecond
+
+	// crdEstablishTimeout is the maximum time to wait for a single CRD to
+	// become established.
+	crdEstablishTimeout = time.Minute
+)
+
+// ApplyAll creates or updates a list of objects in dependency order.
+// Objects are sorted by kind, so that e.g. Namespaces are applied before the
+// objects inside them and CRDs are applied before custom resources. After all
+// CRDs have been applied, ApplyAll waits for them to become established and
+// refreshes the discovery information. Each CRD is given crdEstablishTimeout
+// to become established, otherwise its result reports the last state seen.
+// The resource of each object is derived from its apiVersion and kind (see
+// ApplyObject).
+// A failing object does not stop the remaining objects from being applied.
+// A result is returned for each object, in the order the objects were applied.
+// If any object failed, ErrApplyFailed is returned.
+func (k8s *Client) ApplyAll(objects []NamedObject, options metav1.ApplyOptions, ctx context.Context) ([]ApplyResult, error) {
+	sorted := sortForApply(objects)
+	results := make([]ApplyResult, 0, len(sorted))
+	pendingCRDs := []int{}
+
+	for _, obj := range sorted {
+		// CRDs are sorted into a single block, so the first non-CRD marks the
+		// point where all CRDs have been applied.
+		if len(pendingCRDs) > 0 && !isCustomResourceDefinition(obj) {
+			k8s.waitForCustomResourceDefinitions(results, pendingCRDs, ctx)
+			pendingCRDs = pendingCRDs[:0]
+		}
+
+		result := ApplyResult{Object: obj}
+
+		var namespace string
+		result.Resource, namespace, result.Err = k8s.resolveObjectScope(obj)
+		if result.Err == nil {
+			_, result.Err = k8s.apply(result.Resource, namespace, obj, options, ctx)
+		}
+
+		if result.Err == nil && isCustomResourceDefinition(obj) {
+			pendingCRDs = append(pendingCRDs, len(results))
+		}
+		results = append(results, result)
+	}
+
+	if len(pendingCRDs) > 0 {
+		k8s.waitForCustomResourceDefinitions(results, pendingCRDs, ctx)
+	}
+
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return results, ErrApplyFailed{Failed: failed, Total: len(results)}
+	}
+	return results, nil
+}
+
+// waitForCustomResourceDefinitions waits for the CRDs at the given result
+// indexes to become established. Errors are stored in the corresponding
+// result. Discovery information is refreshed afterwards, so that the new
+// kinds can be resolved.
+func (k8s *Client) waitForCustomResourceDefinitions(results []ApplyResult, indexes []int, ctx context.Context) {
+	for _, idx := range indexes {
+		if err := k8s.waitForCustomResourceDefinition(results[idx].Object.GetName(), ctx); err != nil {
+			results[idx].Err = err
+		}
+	}
+
+	if err := k8s.RefreshDiscovery(ctx); err != nil {
+		for _, idx := range indexes {
+			if results[idx].Err == nil {
+				results[idx].Err = err
+			}
+		}
+	}
+}
+
+// waitForCustomResourceDefinition waits up to crdEstablishTimeout for a given
+// CRD to become established. If it does not, the returned error contains the
+// last error returned by the API server or the last Established condition.
+func (k8s *Client) waitForCustomResourceDefinition(name string, ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, crdEstablishTimeout)
+	defer cancel()
+
+	lastState := "CRD not found"
+	err := wait.PollUntilContextCancel(ctx, crdPollInterval, true, func(ctx context.Context) (bool, error) {
+		crd, err := k8s.GetNamedObject(ResourceCustomResourceDefinition, name, ctx)
+		if err != nil {
+			// The CRD might not be visible yet
+			lastState = err.Error()
+			return false, nil
+		}
+		if hasCondition(crd, "Established", "True") {
+			return true, nil
+		}
+		lastState = describeCondition(crd, "Established")
+		return false, nil
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to wait for CRD %s to become established (%s)", name, lastState)
+	}
+	return nil
+}
+
+// sortForApply returns a copy of the given list, sorted by applyOrder.
+// The order of objects of the same kind is preserved.
+func sortForApply(objects []NamedObject) []NamedObject {
+	priority := make(map[string]int, len(applyOrder))
+	for i, kind := range applyOrder {
+		priority[kind] = i
+	}
+
+	getPriority := func(obj NamedObject) int {
+		if p, ok := priority[obj.GetKind()]; ok {
+			return p
+		}
+		return len(applyOrder)
+	}
+
+	sorted := make([]NamedObject, len(objects))
+	copy(sorted, objects)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return getPriority(sorted[i]) < getPriority(sorted[j])
+	})
+
+	return sorted
+}
+
+// isCustomResourceDefinition returns true if the given object is a CRD.
+func isCustomResourceDefinition(obj NamedObject) bool {
+	return obj.GetKind() == "CustomResourceDefinition" &&
+		schema.FromAPIVersionAndKind(obj.GetVersion(), "").Group == ResourceCustomResourceDefinition.Group
+}
+
+// describeCondition returns the status, reason and message of the status
+// condition with the given type in a human readable form.
+func describeCondition(obj NamedObject, conditionType string) string {
+	conditions, _ := obj.GetList(Path{"status", "conditions"})
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok || condition["type"] != conditionType {
+			continue
+		}
+		return fmt.Sprintf("%s=%v, reason: %v, message: %v", conditionType, condition["status"], condition["reason"], condition["message"])
+	}
+	return fmt.Sprintf("condition %s not set", conditionType)
+}
+
+// hasCondition returns true if the object has a status condition of the given
+// type set to the given status.
+func hasCondition(obj NamedObject, conditionType, status string) bool {
+	conditions, err := obj.GetList(Path{"status", "conditions"})
+	if err != nil {
+		return false
+	}
+
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if condition["type"] == conditionType {
+			return condition["status"] == status
+		}
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/discovery/cached/memory"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func newTestObject(apiVersion, kind, name string) NamedObject {
+	obj := NewNamedObject(name)
+	obj["apiVersion"] = apiVersion
+	obj["kind"] = kind
+	return obj
+}
+
+func TestSortForApply(t *testing.T) {
+	objects := []NamedObject{
+		newTestObject("example.com/v1", "Widget", "widget"),
+		newTestObject("apps/v1", "Deployment", "deployment"),
+		newTestObject("v1", "ConfigMap", "config-b"),
+		newTestObject("rbac.authorization.k8s.io/v1", "Role", "role"),
+		newTestObject("v1", "ConfigMap", "config-a"),
+		newTestObject("apiextensions.k8s.io/v1", "CustomResourceDefinition", "widgets.example.com"),
+		newTestObject("v1", "Namespace", "namespace"),
+	}
+
+	names := []string{}
+	for _, obj := range sortForApply(objects) {
+		names = append(names, obj.GetName())
+	}
+
+	assert.Equal(t, []string{
+		"namespace",
+		"widgets.example.com",
+		"role",
+		"config-b",
+		"config-a",
+		"deployment",
+		"widget",
+	}, names)
+
+	// The input must not be modified
+	assert.Equal(t, "widget", objects[0].GetName())
+}
+
+func TestApplyAll(t *testing.T) {
+	fake := &k8stesting.Fake{Resources: append(append([]*metav1.APIResourceList{}, testAPIResources...), &metav1.APIResourceList{
+		GroupVersion: "apiextensions.k8s.io/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "customresourcedefinitions", SingularName: "customresourcedefinition", Kind: "CustomResourceDefinition", Namespaced: false},
+		},
+	})}
+	discoveryClient := memory.NewMemCacheClient(&fakediscovery.FakeDiscovery{Fake: fake})
+
+	crd := &unstructured.Unstructured{Object: newTestObject("apiextensions.k8s.io/v1", "CustomResourceDefinition", "widgets.example.com")}
+	unstructured.SetNestedSlice(crd.Object, []interface{}{
+		map[string]interface{}{"type": "Established", "status": "True"},
+	}, "status", "conditions")
+
+	fakeClient := newTestDynamicClient(crd)
+	fakeClient.PrependReactor("patch", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		// Installing the CRD makes the new kind discoverable
+		if action.GetResource() == ResourceCustomResourceDefinition {
+			fake.Resources = append(fake.Resources, &metav1.APIResourceList{
+				GroupVersion: "example.com/v1",
+				APIResources: []metav1.APIResource{
+					{Name: "widgets", SingularName: "widget", Kind: "Widget", Namespaced: true},
+				},
+			})
+		}
+		return true, newTestPatchResult(action), nil
+	})
+
+	client := Client{
+		client:              fakeClient,
+		discoveryClient:     discoveryClient,
+		groupResourceMapper: newRESTMapper(discoveryClient),
+	}
+
+	// Populate discovery before the CRD is installed
+	_, err := client.ResolveResource("deploy")
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	results, err := client.ApplyAll([]NamedObject{
+		newTestObject("example.com/v1", "Widget", "widget"),
+		newTestObject("apps/v1", "Deployment", "deployment"),
+		newTestObject("example.com/v1", "Unknown", "unknown"),
+		newTestObject("apiextensions.k8s.io/v1", "CustomResourceDefinition", "widgets.example.com"),
+		newTestObject("v1", "Namespace", "namespace"),
+	}, metav1.ApplyOptions{FieldManager: "test"}, ctx)
+
+	assert.Equal(t, ErrApplyFailed{Failed: 1, Total: 5}, err)
+	assert.Len(t, results, 5)
+
+	assert.Equal(t, ResourceNamespace, results[0].Resource)
+	assert.Equal(t, ResourceCustomResourceDefinition, results[1].Resource)
+	assert.Equal(t, ResourceDeployment, results[2].Resource)
+	assert.Equal(t, "widgets", results[3].Resource.Resource)
+	assert.NoError(t, results[3].Err)
+	assert.Equal(t, "unknown", results[4].Object.GetName())
+	assert.Error(t, results[4].Err)
+}
+
+func TestApplyAllCRDTimeout(t *testing.T) {
+	defer func(interval, timeout time.Duration) {
+		crdPollInterval, crdEstablishTimeout = interval, timeout
+	}(crdPollInterval, crdEstablishTimeout)
+	crdPollInterval, crdEstablishTimeout = 10*time.Millisecond, 100*time.Millisecond
+
+	crd := newTestObject("apiextensions.k8s.io/v1", "CustomResourceDefinition", "widgets.example.com")
+	assert.NoError(t, crd.Set(Path{"status", "conditions"}, []interface{}{
+		map[string]interface{}{"type": "Established", "status": "False", "reason": "Installing"},
+	}))
+
+	client := MustNewFakeClient(crd)
+
+	// The CRD never becomes established, so ApplyAll must not block
+	results, err := client.ApplyAll([]NamedObject{crd}, metav1.ApplyOptions{FieldManager: "test"}, context.Background())
+	assert.Equal(t, ErrApplyFailed{Failed: 1, Total: 1}, err)
+	assert.ErrorIs(t, results[0].Err, context.DeadlineExceeded)
+	assert.ErrorContains(t, results[0].Err, "Established=False, reason: Installing")
+}
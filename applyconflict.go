@@ -0,0 +1,111 @@
+package kubernetes
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ApplyForcingConflicts creates or updates a given kubernetes object like
+// Apply. If the request conflicts with other field managers and all
+// conflicting fields are covered by forcePaths, the request is retried with
+// options.Force set, taking over ownership of these fields.
+// Paths use server-side apply notation, e.g. ".spec.replicas". A path also
+// covers all fields below it, e.g. ".spec.template" covers
+// ".spec.template.spec.containers[name=\"app\"].image".
+// If any conflicting field is not covered, ErrApplyConflict is returned.
+func (k8s *Client) ApplyForcingConflicts(resource schema.GroupVersionResource, object NamedObject, options metav1.ApplyOptions, forcePaths []string, ctx context.Context) error {
+	err := k8s.Apply(resource, object, options, ctx)
+
+	var conflictErr ErrApplyConflict
+	if !errors.As(err, &conflictErr) || options.Force {
+		return err
+	}
+
+	for _, conflict := range conflictErr.Conflicts {
+		if !isPathCovered(conflict.Path, forcePaths) {
+			return err
+		}
+	}
+
+	options.Force = true
+	return k8s.Apply(resource, object, options, ctx)
+}
+
+// newApplyConflictError converts a server-side apply conflict into an
+// ErrApplyConflict. If err is not an apply conflict, nil is returned.
+func newApplyConflictError(err error, identifier string) error {
+	if !apierrors.IsConflict(err) {
+		return nil
+	}
+
+	var status apierrors.APIStatus
+	if !errors.As(err, &status) || status.Status().Details == nil {
+		return nil
+	}
+
+	conflicts := []ApplyConflict{}
+	for _, cause := range status.Status().Details.Causes {
+		if cause.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+		conflicts = append(conflicts, ApplyConflict{
+			Path:    cause.Field,
+			Manager: parseConflictManager(cause.Message),
+			Message: cause.Message,
+		})
+	}
+
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	return ErrApplyConflict{
+		Object:    identifier,
+		Conflicts: conflicts,
+		Err:       err,
+	}
+}
+
+// parseConflictManager extracts the manager name from a conflict message.
+// The API server reports conflicts as `conflict with "manager"`, optionally
+// followed by details like the API version used.
+func parseConflictManager(message string) string {
+	quoted, found := strings.CutPrefix(message, "conflict with ")
+	if !found {
+		return ""
+	}
+
+	quoted, err := strconv.QuotedPrefix(quoted)
+	if err != nil {
+		return ""
+	}
+
+	manager, err := strconv.Unquote(quoted)
+	if err != nil {
+		return ""
+	}
+	return manager
+}
+
+// isPathCovered returns true if path equals one of the given paths or is
+// located below one of them.
+func isPathCovered(path string, coveringPaths []string) bool {
+	for _, covering := range coveringPaths {
+		if path == covering {
+			return true
+		}
+		if strings.HasPrefix(path, covering) {
+			next := path[len(covering)]
+			if next == '.' || next == '[' {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func newTestConflictError() error {
+	return apierrors.NewApplyConflict([]metav1.StatusCause{
+		{
+			Type:    metav1.CauseTypeFieldManagerConflict,
+			Message: `conflict with "kube-controller-manager" using apps/v1`,
+			Field:   ".spec.replicas",
+		},
+		{
+			Type:    metav1.CauseTypeFieldManagerConflict,
+			Message: `conflict with "helm"`,
+			Field:   `.spec.template.spec.containers[name="app"].image`,
+		},
+	}, "Apply failed with 2 conflicts")
+}
+
+func TestNewApplyConflictError(t *testing.T) {
+	err := newApplyConflictError(newTestConflictError(), "default/test")
+
+	var conflictErr ErrApplyConflict
+	assert.ErrorAs(t, err, &conflictErr)
+	assert.Equal(t, "default/test", conflictErr.Object)
+	assert.Equal(t, []ApplyConflict{
+		{
+			Path:    ".spec.replicas",
+			Manager: "kube-controller-manager",
+			Message: `conflict with "kube-controller-manager" using apps/v1`,
+		},
+		{
+			Path:    `.spec.template.spec.containers[name="app"].image`,
+			Manager: "helm",
+			Message: `conflict with "helm"`,
+		},
+	}, conflictErr.Conflicts)
+	assert.True(t, apierrors.IsConflict(err))
+
+	assert.Nil(t, newApplyConflictError(apierrors.NewNotFound(schema.GroupResource{Resource: "pods"}, "test"), "test"))
+}
+
+func TestIsPathCovered(t *testing.T) {
+	assert.True(t, isPathCovered(".spec.replicas", []string{".spec.replicas"}))
+	assert.True(t, isPathCovered(`.spec.template.spec.containers[name="app"].image`, []string{".spec.template"}))
+	assert.True(t, isPathCovered(`.spec.containers[name="app"]`, []string{".spec.containers"}))
+	assert.False(t, isPathCovered(".spec.replicasCount", []string{".spec.replicas"}))
+	assert.False(t, isPathCovered(".spec", []string{".spec.replicas"}))
+	assert.False(t, isPathCovered(".spec.replicas", nil))
+}
+
+func TestApplyForcingConflicts(t *testing.T) {
+	fakeClient := newTestDynamicClient()
+	fakeClient.PrependReactor("patch", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		options := action.(k8stesting.PatchActionImpl).PatchOptions
+		if options.Force != nil && *options.Force {
+			return true, newTestPatchResult(action), nil
+		}
+		return true, nil, newTestConflictError()
+	})
+	client := Client{client: fakeClient}
+
+	obj := newTestObject("apps/v1", "Deployment", "test")
+	ctx := context.Background()
+	options := metav1.ApplyOptions{FieldManager: "test"}
+
+	err := client.Apply(ResourceDeployment, obj, options, ctx)
+	assert.ErrorAs(t, err, new(ErrApplyConflict))
+
+	err = client.ApplyForcingConflicts(ResourceDeployment, obj, options, []string{".spec.replicas"}, ctx)
+	assert.ErrorAs(t, err, new(ErrApplyConflict))
+	assert.Len(t, fakeClient.Actions(), 2)
+
+	err = client.ApplyForcingConflicts(ResourceDeployment, obj, options, []string{".spec.replicas", ".spec.template"}, ctx)
+	assert.NoError(t, err)
+	assert.Len(t, fakeClient.Actions(), 4)
+}
@@ -0,0 +1,96 @@
+package kubernetes
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// CanI checks if the client's credentials allow a given verb on a given
+// resource, using a SelfSubjectAccessReview. Subresources can be checked by
+// passing them as part of the resource, like kubectl does, e.g.
+// "serviceaccounts/token" for GetServiceAccountToken.
+// An empty namespace checks access across all namespaces, an empty name
+// checks access to all objects of the given resource.
+func (k8s *Client) CanI(verb string, resource schema.GroupVersionResource, namespace, name string, ctx context.Context) (bool, error) {
+	review := &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: newResourceAttributes(verb, resource, namespace, name),
+		},
+	}
+
+	response, err := k8s.apiClient.authorizationv1.SelfSubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to check access to %s %s", verb, resource.String())
+	}
+
+	return response.Status.Allowed, nil
+}
+
+// CanSubject checks if a given user with the given groups is allowed a given
+// verb on a given resource, using a SubjectAccessReview. The user may also be
+// a service account, e.g. "system:serviceaccount:<namespace>:<name>".
+// See CanI for the remaining parameters. The client's credentials require the
+// "create" permission on subjectaccessreviews.
+func (k8s *Client) CanSubject(user string, groups []string, verb string, resource schema.GroupVersionResource, namespace, name string, ctx context.Context) (bool, error) {
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:               user,
+			Groups:             groups,
+			ResourceAttributes: newResourceAttributes(verb, resource, namespace, name),
+		},
+	}
+
+	response, err := k8s.apiClient.authorizationv1.SubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to check access of %s to %s %s", user, verb, resource.String())
+	}
+
+	return response.Status.Allowed, nil
+}
+
+// ListPermissions returns the rules granted to the client's credentials in a
+// given namespace, using a SelfSubjectRulesReview. The result is meant for
+// display and debugging. Use CanI for authorization decisions, as the list
+// may be incomplete, e.g. when a webhook authorizer is used. Incomplete lists
+// are flagged in the returned status.
+// Unlike CanI, a namespace is required. If namespace is empty,
+// ErrMissingNamespace is returned.
+func (k8s *Client) ListPermissions(namespace string, ctx context.Context) (authorizationv1.SubjectRulesReviewStatus, error) {
+	if namespace == "" {
+		return authorizationv1.SubjectRulesReviewStatus{}, ErrMissingNamespace{}
+	}
+
+	review := &authorizationv1.SelfSubjectRulesReview{
+		Spec: authorizationv1.SelfSubjectRulesReviewSpec{
+			Namespace: namespace,
+		},
+	}
+
+	response, err := k8s.apiClient.authorizationv1.SelfSubjectRulesReviews().Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return authorizationv1.SubjectRulesReviewStatus{}, errors.Wrapf(err, "failed to list permissions in namespace %s", namespace)
+	}
+
+	return response.Status, nil
+}
+
+// newResourceAttributes creates the attributes of an access review.
+// Subresources are split off the resource, e.g. "pods/log".
+func newResourceAttributes(verb string, resource schema.GroupVersionResource, namespace, name string) *authorizationv1.ResourceAttributes {
+	resourceName, subresource, _ := strings.Cut(resource.Resource, "/")
+
+	return &authorizationv1.ResourceAttributes{
+		Namespace:   namespace,
+		Verb:        verb,
+		Group:       resource.Group,
+		Version:     resource.Version,
+		Resource:    resourceName,
+		Subresource: subresource,
+		Name:        name,
+	}
+}
@@ -0,0 +1,99 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestCanI(t *testing.T) {
+	client := MustNewFakeClient()
+	ctx := context.Background()
+
+	var attributes *authorizationv1.ResourceAttributes
+	client.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		attributes = review.Spec.ResourceAttributes
+		review.Status.Allowed = attributes.Verb == "get"
+		return true, review, nil
+	})
+
+	token := schema.GroupVersionResource{Version: "v1", Resource: "serviceaccounts/token"}
+	allowed, err := client.CanI("create", token, "test", "builder", ctx)
+	assert.NoError(t, err)
+	assert.False(t, allowed)
+	assert.Equal(t, authorizationv1.ResourceAttributes{
+		Namespace:   "test",
+		Verb:        "create",
+		Version:     "v1",
+		Resource:    "serviceaccounts",
+		Subresource: "token",
+		Name:        "builder",
+	}, *attributes)
+
+	allowed, err = client.CanI("get", ResourceDeployment, "", "", ctx)
+	assert.NoError(t, err)
+	assert.True(t, allowed)
+	assert.Equal(t, "apps", attributes.Group)
+	assert.Equal(t, "deployments", attributes.Resource)
+	assert.Empty(t, attributes.Subresource)
+
+	injected := errors.New("injected")
+	client.InjectError("create", "selfsubjectaccessreviews", injected)
+	_, err = client.CanI("get", ResourcePod, "", "", ctx)
+	assert.ErrorIs(t, err, injected)
+}
+
+func TestCanSubject(t *testing.T) {
+	client := MustNewFakeClient()
+	ctx := context.Background()
+
+	client.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		review.Status.Allowed = review.Spec.User == "admin" || (len(review.Spec.Groups) > 0 && review.Spec.Groups[0] == "system:masters")
+		return true, review, nil
+	})
+
+	allowed, err := client.CanSubject("admin", nil, "delete", ResourcePod, "test", "", ctx)
+	assert.NoError(t, err)
+	assert.True(t, allowed)
+
+	allowed, err = client.CanSubject("user", []string{"system:masters"}, "delete", ResourcePod, "test", "", ctx)
+	assert.NoError(t, err)
+	assert.True(t, allowed)
+
+	allowed, err = client.CanSubject("user", nil, "delete", ResourcePod, "test", "", ctx)
+	assert.NoError(t, err)
+	assert.False(t, allowed)
+}
+
+func TestListPermissions(t *testing.T) {
+	client := MustNewFakeClient()
+	ctx := context.Background()
+
+	client.PrependReactor("create", "selfsubjectrulesreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectRulesReview)
+		review.Status.ResourceRules = []authorizationv1.ResourceRule{{
+			Verbs:     []string{"get", "list"},
+			APIGroups: []string{""},
+			Resources: []string{"pods"},
+		}}
+		review.Status.Incomplete = review.Spec.Namespace != "test"
+		return true, review, nil
+	})
+
+	permissions, err := client.ListPermissions("test", ctx)
+	assert.NoError(t, err)
+	assert.False(t, permissions.Incomplete)
+	assert.Len(t, permissions.ResourceRules, 1)
+	assert.Equal(t, []string{"pods"}, permissions.ResourceRules[0].Resources)
+
+	_, err = client.ListPermissions("", ctx)
+	assert.ErrorIs(t, err, ErrMissingNamespace{})
+}
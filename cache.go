@@ -0,0 +1,236 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ObjectReader is implemented by Client and ObjectCache. It can be used to
+// switch between direct API calls and cached lookups.
+type ObjectReader interface {
+	GetNamedObject(resource schema.GroupVersionResource, name string, ctx context.Context) (NamedObject, error)
+	GetNamespacedObject(resource schema.GroupVersionResource, name, namespace string, ctx context.Context) (NamedObject, error)
+	ListAllObjects(resource schema.GroupVersionResource, labelSelector, fieldSelector string, ctx context.Context) ([]NamedObject, error)
+	ListAllObjectsInNamespace(resource schema.GroupVersionResource, namespace, labelSelector, fieldSelector string, ctx context.Context) ([]NamedObject, error)
+	ListAllObjectsInNamespaceMatching(resource schema.GroupVersionResource, namespace string, labelMatchExpression metav1.LabelSelector, fieldSelector string, ctx context.Context) ([]NamedObject, error)
+	ListAllObjectsMatching(resource schema.GroupVersionResource, labelMatchExpression metav1.LabelSelector, fieldSelector string, ctx context.Context) ([]NamedObject, error)
+}
+
+var (
+	_ ObjectReader = (*Client)(nil)
+	_ ObjectReader = (*ObjectCache)(nil)
+)
+
+// ObjectCache is a local, informer backed store for a fixed set of resources.
+// Lookups are served from memory and do not hit the kubernetes API.
+// Objects returned by the cache are copies and can be modified freely.
+type ObjectCache struct {
+	factory   dynamicinformer.DynamicSharedInformerFactory
+	informers map[schema.GroupVersionResource]informers.GenericInformer
+}
+
+// NewCache creates a new cache for the given resources. The cache needs to be
+// started via Start before it can be used. Use WaitForSync to block until all
+// resources have been listed initially.
+func (k8s *Client) NewCache(resources ...schema.GroupVersionResource) *ObjectCache {
+	objCache := &ObjectCache{
+		factory:   dynamicinformer.NewDynamicSharedInformerFactory(k8s.client, 0),
+		informers: make(map[schema.GroupVersionResource]informers.GenericInformer, len(resources)),
+	}
+
+	for _, resource := range resources {
+		informer := objCache.factory.ForResource(resource)
+		// Informers are only started if they have been requested once
+		informer.Informer()
+		objCache.informers[resource] = informer
+	}
+
+	return objCache
+}
+
+// Start starts all informers of this cache. The informers will be stopped
+// when the given context is done.
+func (c *ObjectCache) Start(ctx context.Context) {
+	c.factory.Start(ctx.Done())
+}
+
+// WaitForSync blocks until all resources of this cache have been listed
+// initially or the context is done. If any resource did not sync, an error is
+// returned.
+func (c *ObjectCache) WaitForSync(ctx context.Context) error {
+	for resource, synced := range c.factory.WaitForCacheSync(ctx.Done()) {
+		if !synced {
+			return ErrCacheNotSynced(resource.String())
+		}
+	}
+	return nil
+}
+
+// GetNamedObject returns a specific kubernetes object from the cache.
+func (c *ObjectCache) GetNamedObject(resource schema.GroupVersionResource, name string, ctx context.Context) (NamedObject, error) {
+	lister, err := c.getLister(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	rawObject, err := lister.Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return namedObjectFromCache(rawObject)
+}
+
+// GetNamespacedObject returns a specific kubernetes object from a specific
+// namespace from the cache.
+func (c *ObjectCache) GetNamespacedObject(resource schema.GroupVersionResource, name, namespace string, ctx context.Context) (NamedObject, error) {
+	lister, err := c.getLister(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	rawObject, err := lister.ByNamespace(namespace).Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return namedObjectFromCache(rawObject)
+}
+
+// ListAllObjects returns a list of all cached objects for a given type.
+func (c *ObjectCache) ListAllObjects(resource schema.GroupVersionResource, labelSelector, fieldSelector string, ctx context.Context) ([]NamedObject, error) {
+	selector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return []NamedObject{}, errors.Wrapf(err, "failed to parse label selector %s", labelSelector)
+	}
+	return c.list(resource, "", selector, fieldSelector)
+}
+
+// ListAllObjectsInNamespace returns a list of all cached objects for a given
+// type in a given namespace.
+func (c *ObjectCache) ListAllObjectsInNamespace(resource schema.GroupVersionResource, namespace, labelSelector, fieldSelector string, ctx context.Context) ([]NamedObject, error) {
+	selector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return []NamedObject{}, errors.Wrapf(err, "failed to parse label selector %s", labelSelector)
+	}
+	return c.list(resource, namespace, selector, fieldSelector)
+}
+
+// ListAllObjectsInNamespaceMatching returns a list of all cached objects
+// matching a given selector struct.
+// Use ParseLabelSelector to create this struct from an existing object.
+func (c *ObjectCache) ListAllObjectsInNamespaceMatching(resource schema.GroupVersionResource, namespace string, labelMatchExpression metav1.LabelSelector, fieldSelector string, ctx context.Context) ([]NamedObject, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&labelMatchExpression)
+	if err != nil {
+		return []NamedObject{}, errors.Wrapf(err, "failed to convert label selector")
+	}
+	return c.list(resource, namespace, selector, fieldSelector)
+}
+
+// ListAllObjectsMatching returns a list of all cached objects matching a given
+// selector struct.
+// Use ParseLabelSelector to create this struct from an existing object.
+func (c *ObjectCache) ListAllObjectsMatching(resource schema.GroupVersionResource, labelMatchExpression metav1.LabelSelector, fieldSelector string, ctx context.Context) ([]NamedObject, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&labelMatchExpression)
+	if err != nil {
+		return []NamedObject{}, errors.Wrapf(err, "failed to convert label selector")
+	}
+	return c.list(resource, "", selector, fieldSelector)
+}
+
+// list returns all cached objects of a given type matching the given
+// selectors. If namespace is left empty, objects from all namespaces are
+// returned. Field selectors are evaluated on the cached objects.
+func (c *ObjectCache) list(resource schema.GroupVersionResource, namespace string, labelSelector labels.Selector, fieldSelector string) ([]NamedObject, error) {
+	lister, err := c.getLister(resource)
+	if err != nil {
+		return []NamedObject{}, err
+	}
+
+	fieldMatcher, err := fields.ParseSelector(fieldSelector)
+	if err != nil {
+		return []NamedObject{}, errors.Wrapf(err, "failed to parse field selector %s", fieldSelector)
+	}
+
+	var rawObjects []runtime.Object
+	if len(namespace) > 0 {
+		rawObjects, err = lister.ByNamespace(namespace).List(labelSelector)
+	} else {
+		rawObjects, err = lister.List(labelSelector)
+	}
+	if err != nil {
+		return []NamedObject{}, err
+	}
+
+	var itemErrors ErrPartialList
+	resultList := make([]NamedObject, 0, len(rawObjects))
+	for _, rawObject := range rawObjects {
+		obj, parseErr := namedObjectFromCache(rawObject)
+		if !fieldMatcher.Matches(namedObjectFields(obj)) {
+			continue
+		}
+		if parseErr != nil {
+			itemErrors = append(itemErrors, ErrListItem{Index: len(resultList), Err: parseErr})
+		}
+		resultList = append(resultList, obj)
+	}
+
+	if len(itemErrors) > 0 {
+		return resultList, itemErrors
+	}
+	return resultList, nil
+}
+
+// getLister returns the lister for a given resource or ErrNotCached if the
+// resource is not part of this cache.
+func (c *ObjectCache) getLister(resource schema.GroupVersionResource) (cache.GenericLister, error) {
+	informer, ok := c.informers[resource]
+	if !ok {
+		return nil, ErrNotCached(resource.String())
+	}
+	return informer.Lister(), nil
+}
+
+// namedObjectFromCache converts a cached object into a NamedObject.
+// The object is copied, as the informer's objects must not be modified.
+func namedObjectFromCache(rawObject runtime.Object) (NamedObject, error) {
+	unstructuredObj, ok := rawObject.(*unstructured.Unstructured)
+	if !ok {
+		return nil, ErrIncorrectType(fmt.Sprintf("%T", rawObject))
+	}
+	return NamedObjectFromUnstructured(*unstructuredObj.DeepCopy())
+}
+
+// namedObjectFields implements fields.Fields on top of a NamedObject, so that
+// field selectors like "metadata.name=test" can be evaluated locally.
+type namedObjectFields NamedObject
+
+// Has returns true if the given, dot separated field exists.
+func (f namedObjectFields) Has(field string) bool {
+	return NamedObject(f).Has(strings.Split(field, "."))
+}
+
+// Get returns the value of the given, dot separated field as a string.
+// If the field does not exist, an empty string is returned.
+func (f namedObjectFields) Get(field string) string {
+	value, err := NamedObject(f).Get(strings.Split(field, "."))
+	if err != nil || value == nil {
+		return ""
+	}
+	if str, ok := value.(string); ok {
+		return str
+	}
+	return fmt.Sprint(value)
+}
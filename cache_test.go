@@ -0,0 +1,56 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestObjectCache(t *testing.T) {
+	labeled := newTestConfigMap("labeled")
+	labeled.SetLabels(map[string]string{"app": "test"})
+	client := Client{client: newTestDynamicClient(labeled, newTestConfigMap("unlabeled"))}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	objCache := client.NewCache(ResourceConfigMap)
+	objCache.Start(ctx)
+	assert.NoError(t, objCache.WaitForSync(ctx))
+
+	obj, err := objCache.GetNamespacedObject(ResourceConfigMap, "labeled", "default", ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "labeled", obj.GetName())
+
+	// Returned objects must not modify the cache
+	assert.NoError(t, obj.SetLabel("app", "modified"))
+	obj, err = objCache.GetNamespacedObject(ResourceConfigMap, "labeled", "default", ctx)
+	assert.NoError(t, err)
+	assert.True(t, obj.IsLabelSetTo("app", "test"))
+
+	objects, err := objCache.ListAllObjectsInNamespace(ResourceConfigMap, "default", "", "", ctx)
+	assert.NoError(t, err)
+	assert.Len(t, objects, 2)
+
+	objects, err = objCache.ListAllObjects(ResourceConfigMap, "app=test", "", ctx)
+	assert.NoError(t, err)
+	assert.Len(t, objects, 1)
+
+	objects, err = objCache.ListAllObjectsMatching(ResourceConfigMap, metav1.LabelSelector{
+		MatchLabels: map[string]string{"app": "test"},
+	}, "", ctx)
+	assert.NoError(t, err)
+	assert.Len(t, objects, 1)
+
+	objects, err = objCache.ListAllObjects(ResourceConfigMap, "", "metadata.name=unlabeled", ctx)
+	assert.NoError(t, err)
+	assert.Len(t, objects, 1)
+	assert.Equal(t, "unlabeled", objects[0].GetName())
+
+	_, err = objCache.ListAllObjects(schema.GroupVersionResource{Version: "v1", Resource: "pods"}, "", "", ctx)
+	assert.ErrorAs(t, err, new(ErrNotCached))
+}
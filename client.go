@@ -2,8 +2,7 @@ package kubernetes
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -15,70 +14,152 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
 	"k8s.io/client-go/dynamic"
+	authorizationv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
+	coordinationv1client "k8s.io/client-go/kubernetes/typed/coordination/v1"
 	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	restclient "k8s.io/client-go/rest"
-	"k8s.io/client-go/restmapper"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
 // Client allows communication with the kubernetes API.
 type Client struct {
+	config              *restclient.Config
 	apiClient           typedClients
 	client              dynamic.Interface
-	discoveryClient     *discovery.DiscoveryClient
+	discoveryClient     discovery.CachedDiscoveryInterface
 	groupResourceMapper meta.RESTMapper
 }
 
 // typedClients holds kubernetes clients for different API groups.
 type typedClients struct {
-	corev1 *corev1client.CoreV1Client
+	corev1          corev1client.CoreV1Interface
+	coordinationv1  coordinationv1client.CoordinationV1Interface
+	authorizationv1 authorizationv1client.AuthorizationV1Interface
 }
 
 // NewClusterClient creates a new kubernetes client for the current cluster.
-func NewClusterClient() (*Client, error) {
-	return NewClientUsingContext("", "")
+func NewClusterClient(options ...ClientOption) (*Client, error) {
+	return NewClientUsingContext("", "", options...)
 }
 
 // NewClient creates a new kubernetes client for a given path to a kubeconfig.
 // The client will use the default context from the kubeconfig file.
-func NewClient(path string) (*Client, error) {
-	return NewClientUsingContext(path, "")
+func NewClient(path string, options ...ClientOption) (*Client, error) {
+	return NewClientUsingContext(path, "", options...)
 }
 
 // NewClientUsingContext creates a new kubernetes client for a given path to a
 // kubeconfig file. If no file is given, an in-cluster client will be created.
+// The path may contain a list of files, separated like the KUBECONFIG
+// environment variable (":" on Linux and macOS, ";" on Windows). In that case
+// the files are merged, with the first file taking precedence.
 // The context parameter can be used to specify a specific context from the
 // kubeconfig file. When left empty, the default context will be used.
-func NewClientUsingContext(path, context string) (*Client, error) {
-	var (
-		err    error
-		config *restclient.Config
-	)
+// If WithRESTConfig is passed, path and context are ignored.
+func NewClientUsingContext(path, context string, options ...ClientOption) (*Client, error) {
+	var err error
 
-	k8sClient := Client{}
+	opts := newClientOptions(options)
+	config := opts.config
 
-	if path == "" {
+	switch {
+	case config != nil:
+		// Prebuilt config passed via WithRESTConfig
+
+	case path == "":
 		// In cluster client if path is empty
 		config, err = restclient.InClusterConfig()
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed to build in-cluster kubeconfig")
 		}
-	} else {
+
+	default:
 		// Out of cluster client if path is given.
-		rules := clientcmd.ClientConfigLoadingRules{
-			ExplicitPath: path,
-		}
 		// Support context overrides
 		overrides := clientcmd.ConfigOverrides{
 			CurrentContext: context,
 		}
-		config, err = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(&rules, &overrides).ClientConfig()
+		config, err = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(newLoadingRules(path), &overrides).ClientConfig()
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed to load kubeconfig from %s", path)
 		}
 	}
 
+	return newClientForConfig(opts.apply(config))
+}
+
+// NewClientUsingDefaultConfig creates a new kubernetes client using the same
+// kubeconfig resolution as kubectl. The files listed in the KUBECONFIG
+// environment variable are merged or, if KUBECONFIG is not set,
+// ~/.kube/config is used. If no kubeconfig can be found, the in-cluster config
+// is used.
+// The context parameter can be used to specify a specific context from the
+// kubeconfig. When left empty, the default context will be used.
+func NewClientUsingDefaultConfig(context string, options ...ClientOption) (*Client, error) {
+	opts := newClientOptions(options)
+	config := opts.config
+
+	if config == nil {
+		var err error
+		// Support context overrides
+		overrides := clientcmd.ConfigOverrides{
+			CurrentContext: context,
+		}
+		config, err = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(newLoadingRules(""), &overrides).ClientConfig()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to load default kubeconfig")
+		}
+	}
+
+	return newClientForConfig(opts.apply(config))
+}
+
+// NewClientFromKubeconfigBytes creates a new kubernetes client from the
+// contents of a kubeconfig file. The context parameter can be used to specify
+// a specific context from the kubeconfig. When left empty, the default context
+// will be used.
+// If WithRESTConfig is passed, data and context are ignored.
+func NewClientFromKubeconfigBytes(data []byte, context string, options ...ClientOption) (*Client, error) {
+	opts := newClientOptions(options)
+	if opts.config != nil {
+		return newClientForConfig(opts.apply(opts.config))
+	}
+
+	kubeconfig, err := clientcmd.Load(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse kubeconfig")
+	}
+
+	// Support context overrides
+	overrides := clientcmd.ConfigOverrides{
+		CurrentContext: context,
+	}
+	config, err := clientcmd.NewNonInteractiveClientConfig(*kubeconfig, context, &overrides, nil).ClientConfig()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load kubeconfig")
+	}
+
+	return newClientForConfig(opts.apply(config))
+}
+
+// NewClientFromRESTConfig creates a new kubernetes client from a prebuilt
+// config. The config is copied, so it is not modified by the given options.
+// If config is nil, ErrMissingRESTConfig is returned.
+func NewClientFromRESTConfig(config *restclient.Config, options ...ClientOption) (*Client, error) {
+	if config == nil {
+		return nil, ErrMissingRESTConfig{}
+	}
+	return newClientForConfig(newClientOptions(options).apply(config))
+}
+
+// newClientForConfig creates all API clients required by Client from a given
+// config.
+func newClientForConfig(config *restclient.Config) (*Client, error) {
+	var err error
+	k8sClient := Client{config: config}
+
 	k8sClient.client, err = dynamic.NewForConfig(config)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to create in-cluster kubernetes client")
@@ -89,26 +170,34 @@ func NewClientUsingContext(path, context string) (*Client, error) {
 		return nil, errors.Wrapf(err, "failed to create in-cluster kubernetes core v1 client")
 	}
 
-	k8sClient.discoveryClient, err = discovery.NewDiscoveryClientForConfig(config)
+	k8sClient.apiClient.coordinationv1, err = coordinationv1client.NewForConfig(config)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to create in-cluster kubernetes discovery client")
+		return nil, errors.Wrapf(err, "failed to create in-cluster kubernetes coordination v1 client")
+	}
+
+	k8sClient.apiClient.authorizationv1, err = authorizationv1client.NewForConfig(config)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create in-cluster kubernetes authorization v1 client")
 	}
 
-	groupResources, err := restmapper.GetAPIGroupResources(k8sClient.discoveryClient)
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to create in-cluster kubernetes group resource mapper")
+		return nil, errors.Wrapf(err, "failed to create in-cluster kubernetes discovery client")
 	}
-	k8sClient.groupResourceMapper = restmapper.NewDiscoveryRESTMapper(groupResources)
+
+	// Discovery information is loaded on first use and refreshed when an
+	// unknown resource is requested.
+	k8sClient.discoveryClient = memory.NewMemCacheClient(discoveryClient)
+	k8sClient.groupResourceMapper = newRESTMapper(k8sClient.discoveryClient)
 
 	return &k8sClient, nil
 }
 
 // GetContextsFromConfig reads a kubeconfig file and returns a list of contexts names.
+// The path may contain a list of files, see NewClientUsingContext. If no path
+// is given, the kubeconfig is resolved like in NewClientUsingDefaultConfig.
 func GetContextsFromConfig(path string) ([]string, error) {
-	rules := clientcmd.ClientConfigLoadingRules{
-		ExplicitPath: path,
-	}
-	config := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(&rules, &clientcmd.ConfigOverrides{})
+	config := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(newLoadingRules(path), &clientcmd.ConfigOverrides{})
 	rawConfig, err := config.RawConfig()
 	if err != nil {
 		return []string{}, err
@@ -122,6 +211,27 @@ func GetContextsFromConfig(path string) ([]string, error) {
 	return contextNames, nil
 }
 
+// newLoadingRules returns the rules used to find and merge kubeconfig files.
+// A single path is loaded as-is and must exist. A list of paths is merged
+// following the KUBECONFIG precedence rules, ignoring missing files. If no
+// path is given, the default rules used by kubectl are returned.
+func newLoadingRules(path string) *clientcmd.ClientConfigLoadingRules {
+	if path == "" {
+		return clientcmd.NewDefaultClientConfigLoadingRules()
+	}
+
+	paths := filepath.SplitList(path)
+	if len(paths) == 1 {
+		return &clientcmd.ClientConfigLoadingRules{
+			ExplicitPath: paths[0],
+		}
+	}
+
+	return &clientcmd.ClientConfigLoadingRules{
+		Precedence: paths,
+	}
+}
+
 // GetNamedObject returns a specific kubernetes object
 func (k8s *Client) GetNamedObject(resource schema.GroupVersionResource, name string, ctx context.Context) (NamedObject, error) {
 	resourceHandle := k8s.client.Resource(resource)
@@ -170,126 +280,230 @@ func (k8s *Client) ListAllObjectsMatching(resource schema.GroupVersionResource,
 	return k8s.list(resource, "", labelSelector, fieldSelector, ctx)
 }
 
+// getResourceHandle returns the dynamic resource interface for a given type.
+// If namespace is left empty, a global resource is expected.
+func (k8s *Client) getResourceHandle(resource schema.GroupVersionResource, namespace string) dynamic.ResourceInterface {
+	if len(namespace) > 0 {
+		return k8s.client.Resource(resource).Namespace(namespace)
+	}
+	return k8s.client.Resource(resource)
+}
+
 // list returns a list of objects for a given type.
 // Namespace, labelSelector and fieldSelector are optional arguments. If namespace is left empty,
 // a global resource is expected. If selector is left empty, all objects will
 // be returned.
+// Items that could not be parsed are still returned, together with an
+// ErrPartialList error.
 func (k8s *Client) list(resource schema.GroupVersionResource, namespace, labelSelector, fieldSelector string, ctx context.Context) ([]NamedObject, error) {
-	options := metav1.ListOptions{
+	resultList, _, err := k8s.ListPage(resource, ListOptions{
+		Namespace:     namespace,
 		LabelSelector: labelSelector,
 		FieldSelector: fieldSelector,
-	}
+	}, ctx)
 
-	var resourceHandle dynamic.ResourceInterface
+	return resultList, err
+}
 
-	if len(namespace) > 0 {
-		resourceHandle = k8s.client.Resource(resource).Namespace(namespace)
-	} else {
-		resourceHandle = k8s.client.Resource(resource)
+// Apply creates or updates a given kubernetes object.
+// If a namespace is set, the object will be created in that namespace.
+// If the request conflicts with other field managers, ErrApplyConflict is
+// returned.
+func (k8s *Client) Apply(resource schema.GroupVersionResource, object NamedObject, options metav1.ApplyOptions, ctx context.Context) error {
+	_, err := k8s.apply(resource, object.GetNamespace(), object, options, ctx)
+	return err
+}
+
+// ApplyAndGet creates or updates a given kubernetes object like Apply.
+// The object returned by the API server is returned, including fields set
+// by the server like uid or resourceVersion.
+func (k8s *Client) ApplyAndGet(resource schema.GroupVersionResource, object NamedObject, options metav1.ApplyOptions, ctx context.Context) (NamedObject, error) {
+	return k8s.apply(resource, object.GetNamespace(), object, options, ctx)
+}
+
+// ApplyObject creates or updates a given kubernetes object.
+// The resource is derived from the object's apiVersion and kind. Namespaced
+// objects without a namespace are created in the default namespace, while the
+// namespace is ignored for cluster-wide resources.
+func (k8s *Client) ApplyObject(object NamedObject, options metav1.ApplyOptions, ctx context.Context) error {
+	_, err := k8s.ApplyObjectAndGet(object, options, ctx)
+	return err
+}
+
+// ApplyObjectAndGet creates or updates a given kubernetes object like
+// ApplyObject. The object returned by the API server is returned.
+func (k8s *Client) ApplyObjectAndGet(object NamedObject, options metav1.ApplyOptions, ctx context.Context) (NamedObject, error) {
+	resource, namespace, err := k8s.resolveObjectScope(object)
+	if err != nil {
+		return nil, err
 	}
+	return k8s.apply(resource, namespace, object, options, ctx)
+}
 
-	list, err := resourceHandle.List(ctx, options)
+// apply creates or updates a given kubernetes object in a given namespace.
+// If an empty namespace is given, the object will be treated as a cluster-wide resource.
+// The object returned by the API server is returned.
+func (k8s *Client) apply(resource schema.GroupVersionResource, namespace string, object NamedObject, options metav1.ApplyOptions, ctx context.Context) (NamedObject, error) {
+	identifier := objectIdentifier(object.GetName(), namespace)
+	unstructuredObject := &unstructured.Unstructured{
+		Object: object,
+	}
+
+	rawObject, err := k8s.getResourceHandle(resource, namespace).Apply(ctx, object.GetName(), unstructuredObject, options)
 	if err != nil {
-		return []NamedObject{}, err
-	}
-
-	resultList := make([]NamedObject, 0, len(list.Items))
-	for _, rawObject := range list.Items {
-		obj, parseErr := NamedObjectFromUnstructured(rawObject)
-		if parseErr != nil {
-			if err == nil {
-				err = parseErr
-			} else {
-				err = errors.Wrapf(err, "failed to parse item: %v", parseErr)
-			}
+		if conflictErr := newApplyConflictError(err, identifier); conflictErr != nil {
+			return nil, conflictErr
 		}
-		resultList = append(resultList, obj)
+		return nil, errors.Wrapf(err, "failed to trigger apply for %s", identifier)
 	}
 
-	return resultList, err
+	return NamedObjectFromUnstructured(*rawObject)
 }
 
-// Apply creates or updates a given kubernetes object.
+// Create creates a given kubernetes object. In contrast to Apply, Create
+// fails if the object already exists.
+// If metadata.generateName is set instead of metadata.name, the API server
+// generates a unique name using generateName as prefix.
 // If a namespace is set, the object will be created in that namespace.
-func (k8s *Client) Apply(resource schema.GroupVersionResource, object NamedObject, options metav1.ApplyOptions, ctx context.Context) error {
-	var (
-		resourceHandle dynamic.ResourceInterface
-		identifier     string
-	)
+// The created object is returned, including the generated name, uid and
+// resourceVersion.
+func (k8s *Client) Create(resource schema.GroupVersionResource, object NamedObject, options metav1.CreateOptions, ctx context.Context) (NamedObject, error) {
+	return k8s.create(resource, object.GetNamespace(), object, options, ctx)
+}
 
-	if object.GetNamespace() != "" {
-		resourceHandle = k8s.client.Resource(resource).Namespace(object.GetNamespace())
-		identifier = fmt.Sprintf("%s/%s", object.GetNamespace(), object.GetName())
-	} else {
-		resourceHandle = k8s.client.Resource(resource)
-		identifier = object.GetName()
+// CreateObject creates a given kubernetes object like Create.
+// The resource is derived from the object's apiVersion and kind. Namespaced
+// objects without a namespace are created in the default namespace, while the
+// namespace is ignored for cluster-wide resources.
+func (k8s *Client) CreateObject(object NamedObject, options metav1.CreateOptions, ctx context.Context) (NamedObject, error) {
+	resource, namespace, err := k8s.resolveObjectScope(object)
+	if err != nil {
+		return nil, err
 	}
+	return k8s.create(resource, namespace, object, options, ctx)
+}
 
+// create creates a given kubernetes object in a given namespace.
+// If an empty namespace is given, the object will be treated as a cluster-wide resource.
+func (k8s *Client) create(resource schema.GroupVersionResource, namespace string, object NamedObject, options metav1.CreateOptions, ctx context.Context) (NamedObject, error) {
 	unstructuredObject := &unstructured.Unstructured{
 		Object: object,
 	}
 
-	if _, err := resourceHandle.Apply(ctx, object.GetName(), unstructuredObject, options); err != nil {
-		return errors.Wrapf(err, "failed to trigger apply for %s", identifier)
+	rawObject, err := k8s.getResourceHandle(resource, namespace).Create(ctx, unstructuredObject, options)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create %s", objectIdentifier(object.GetName(), namespace))
 	}
 
-	return nil
+	return NamedObjectFromUnstructured(*rawObject)
 }
 
 // DeleteNamespaced removes a specific kubernetes object from a specific namespace.
 // If an empty namespace is given, the object will be treated as a cluster-wide resource.
 func (k8s *Client) DeleteNamespaced(resource schema.GroupVersionResource, name, namespace string, ctx context.Context) error {
-	var (
-		resourceHandle dynamic.ResourceInterface
-		identifier     string
-	)
-
-	if namespace != "" {
-		resourceHandle = k8s.client.Resource(resource).Namespace(namespace)
-		identifier = fmt.Sprintf("%s/%s", namespace, name)
-	} else {
-		resourceHandle = k8s.client.Resource(resource)
-		identifier = name
-	}
+	return k8s.DeleteWithOptions(resource, name, namespace, metav1.DeleteOptions{}, ctx)
+}
 
-	if err := resourceHandle.Delete(ctx, name, metav1.DeleteOptions{}); err != nil {
-		return errors.Wrapf(err, "failed to trigger delete for %s", identifier)
+// DeleteWithOptions removes a specific kubernetes object from a specific
+// namespace. The options can be used to set the propagation policy, the grace
+// period or preconditions on the object's uid or resourceVersion.
+// If an empty namespace is given, the object will be treated as a cluster-wide resource.
+func (k8s *Client) DeleteWithOptions(resource schema.GroupVersionResource, name, namespace string, options metav1.DeleteOptions, ctx context.Context) error {
+	if err := k8s.getResourceHandle(resource, namespace).Delete(ctx, name, options); err != nil {
+		return errors.Wrapf(err, "failed to trigger delete for %s", objectIdentifier(name, namespace))
 	}
 
 	return nil
 }
 
+// DeleteObject removes a given kubernetes object.
+// The resource is derived from the object's apiVersion and kind. Namespaced
+// objects without a namespace are removed from the default namespace.
+func (k8s *Client) DeleteObject(object NamedObject, ctx context.Context) error {
+	return k8s.DeleteObjectWithOptions(object, metav1.DeleteOptions{}, ctx)
+}
+
+// DeleteObjectWithOptions removes a given kubernetes object like DeleteObject.
+// See DeleteWithOptions for the available options.
+func (k8s *Client) DeleteObjectWithOptions(object NamedObject, options metav1.DeleteOptions, ctx context.Context) error {
+	resource, namespace, err := k8s.resolveObjectScope(object)
+	if err != nil {
+		return err
+	}
+	return k8s.DeleteWithOptions(resource, object.GetName(), namespace, options, ctx)
+}
+
 // Patch applies a set of patches on a given kubernetes object.
 // The patches are applied as json patches.
 func (k8s *Client) Patch(resource schema.GroupVersionResource, object NamedObject, patches []PatchOperation, options metav1.PatchOptions, ctx context.Context) error {
-	var (
-		resourceHandle dynamic.ResourceInterface
-		identifier     string
-	)
+	_, err := k8s.patch(resource, object.GetNamespace(), object, PatchJSON, patches, options, "", ctx)
+	return err
+}
+
+// PatchWithType applies a patch of a given type on a given kubernetes object.
+// For PatchJSON, patch is expected to be a []PatchOperation. All other patch
+// types expect an object fragment as NamedObject or map[string]interface{}.
+// A []byte is sent as-is for all patch types.
+// If a PatchApply request conflicts with other field managers,
+// ErrApplyConflict is returned.
+func (k8s *Client) PatchWithType(resource schema.GroupVersionResource, object NamedObject, patchType types.PatchType, patch interface{}, options metav1.PatchOptions, ctx context.Context) error {
+	_, err := k8s.patch(resource, object.GetNamespace(), object, patchType, patch, options, "", ctx)
+	return err
+}
 
-	if object.GetNamespace() != "" {
-		resourceHandle = k8s.client.Resource(resource).Namespace(object.GetNamespace())
-		identifier = fmt.Sprintf("%s/%s", object.GetNamespace(), object.GetName())
-	} else {
-		resourceHandle = k8s.client.Resource(resource)
-		identifier = object.GetName()
+// PatchObject applies a set of patches on a given kubernetes object.
+// The resource is derived from the object's apiVersion and kind. Namespaced
+// objects without a namespace are expected in the default namespace.
+func (k8s *Client) PatchObject(object NamedObject, patches []PatchOperation, options metav1.PatchOptions, ctx context.Context) error {
+	return k8s.PatchObjectWithType(object, PatchJSON, patches, options, ctx)
+}
+
+// PatchObjectWithType applies a patch of a given type on a given kubernetes
+// object. See PatchWithType for supported patch formats.
+// The resource is derived from the object's apiVersion and kind. Namespaced
+// objects without a namespace are expected in the default namespace.
+func (k8s *Client) PatchObjectWithType(object NamedObject, patchType types.PatchType, patch interface{}, options metav1.PatchOptions, ctx context.Context) error {
+	resource, namespace, err := k8s.resolveObjectScope(object)
+	if err != nil {
+		return err
 	}
+	_, err = k8s.patch(resource, namespace, object, patchType, patch, options, "", ctx)
+	return err
+}
 
-	patchData, err := json.Marshal(patches)
+// patch applies a patch of a given type on a given kubernetes object in a
+// given namespace. If an empty namespace is given, the object will be treated
+// as a cluster-wide resource. If subresource is set, the patch is applied to
+// that subresource, e.g. "status".
+// The object returned by the API server is returned.
+func (k8s *Client) patch(resource schema.GroupVersionResource, namespace string, object NamedObject, patchType types.PatchType, patch interface{}, options metav1.PatchOptions, subresource string, ctx context.Context) (NamedObject, error) {
+	var subresources []string
+	identifier := objectIdentifier(object.GetName(), namespace)
+
+	if subresource != "" {
+		subresources = []string{subresource}
+	}
+
+	patchData, err := marshalPatch(patchType, patch)
 	if err != nil {
-		return errors.Wrapf(err, "failed to marshal patch data for %s", identifier)
+		return nil, errors.Wrapf(err, "failed to marshal patch data for %s", identifier)
 	}
 
-	if _, err := resourceHandle.Patch(ctx, object.GetName(), types.JSONPatchType, patchData, metav1.PatchOptions{}); err != nil {
-		return errors.Wrapf(err, "failed to apply patch for %s", identifier)
+	rawObject, err := k8s.getResourceHandle(resource, namespace).Patch(ctx, object.GetName(), patchType, patchData, options, subresources...)
+	if err != nil {
+		if conflictErr := newApplyConflictError(err, identifier); conflictErr != nil {
+			return nil, conflictErr
+		}
+		return nil, errors.Wrapf(err, "failed to apply patch for %s", identifier)
 	}
 
-	return nil
+	return NamedObjectFromUnstructured(*rawObject)
 }
 
 // GetServiceAccountToken returns a token for a given service account.
 // This requires the calling service to have the necessary permissions for
-// `authentication.k8s.io/tokenrequests`.
+// `authentication.k8s.io/tokenrequests`, i.e. "create" on "serviceaccounts/token".
+// Use CanI to check this up front.
 func (k8s *Client) GetServiceAccountToken(serviceAccountName, namespace string, expiration time.Duration, audiences []string, pod NamedObject, ctx context.Context) (string, error) {
 	expirationSec := int64(expiration.Seconds())
 	var boundPodRef authenticationv1.BoundObjectReference
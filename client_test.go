@@ -0,0 +1,205 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	restclient "k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+const testKubeconfig = `
+apiVersion: v1
+kind: Config
+current-context: dev
+clusters:
+- name: dev
+  cluster:
+    server: https://dev.example.com:6443
+- name: prod
+  cluster:
+    server: https://prod.example.com:6443
+contexts:
+- name: dev
+  context:
+    cluster: dev
+    user: admin
+- name: prod
+  context:
+    cluster: prod
+    user: admin
+users:
+- name: admin
+  user:
+    token: secret
+`
+
+func TestNewClientFromKubeconfigBytes(t *testing.T) {
+	client, err := NewClientFromKubeconfigBytes([]byte(testKubeconfig), "")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://dev.example.com:6443", client.config.Host)
+	assert.Equal(t, "secret", client.config.BearerToken)
+
+	client, err = NewClientFromKubeconfigBytes([]byte(testKubeconfig), "prod", WithUserAgent("test"))
+	assert.NoError(t, err)
+	assert.Equal(t, "https://prod.example.com:6443", client.config.Host)
+	assert.Equal(t, "test", client.config.UserAgent)
+
+	_, err = NewClientFromKubeconfigBytes([]byte(testKubeconfig), "missing")
+	assert.Error(t, err)
+
+	_, err = NewClientFromKubeconfigBytes([]byte("not: [a kubeconfig"), "")
+	assert.Error(t, err)
+
+	// A prebuilt config replaces the kubeconfig
+	config := &restclient.Config{Host: "https://127.0.0.1:6443"}
+	client, err = NewClientFromKubeconfigBytes(nil, "missing", WithRESTConfig(config))
+	assert.NoError(t, err)
+	assert.Equal(t, "https://127.0.0.1:6443", client.config.Host)
+}
+
+// writeTestKubeconfig writes a kubeconfig holding a single context of the
+// given name and returns its path.
+func writeTestKubeconfig(t *testing.T, name string) string {
+	path := filepath.Join(t.TempDir(), name+".yaml")
+	config := fmt.Sprintf(`
+apiVersion: v1
+kind: Config
+current-context: %[1]s
+clusters:
+- name: %[1]s
+  cluster:
+    server: https://%[1]s.example.com:6443
+contexts:
+- name: %[1]s
+  context:
+    cluster: %[1]s
+    user: %[1]s
+users:
+- name: %[1]s
+  user:
+    token: secret
+`, name)
+
+	assert.NoError(t, os.WriteFile(path, []byte(config), 0o600))
+	return path
+}
+
+func TestKubeconfigPrecedence(t *testing.T) {
+	dev, prod := writeTestKubeconfig(t, "dev"), writeTestKubeconfig(t, "prod")
+	kubeconfig := strings.Join([]string{dev, filepath.Join(t.TempDir(), "missing.yaml"), prod}, string(os.PathListSeparator))
+
+	contexts, err := GetContextsFromConfig(kubeconfig)
+	assert.NoError(t, err)
+	sort.Strings(contexts)
+	assert.Equal(t, []string{"dev", "prod"}, contexts)
+
+	// The current context is taken from the first file
+	client, err := NewClientUsingContext(kubeconfig, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://dev.example.com:6443", client.config.Host)
+
+	client, err = NewClientUsingContext(kubeconfig, "prod")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://prod.example.com:6443", client.config.Host)
+
+	// A single path must exist
+	_, err = NewClientUsingContext(filepath.Join(t.TempDir(), "missing.yaml"), "")
+	assert.Error(t, err)
+}
+
+func TestNewClientUsingDefaultConfig(t *testing.T) {
+	dev, prod := writeTestKubeconfig(t, "dev"), writeTestKubeconfig(t, "prod")
+	t.Setenv("KUBECONFIG", prod+string(os.PathListSeparator)+dev)
+
+	contexts, err := GetContextsFromConfig("")
+	assert.NoError(t, err)
+	assert.Len(t, contexts, 2)
+
+	client, err := NewClientUsingDefaultConfig("")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://prod.example.com:6443", client.config.Host)
+
+	client, err = NewClientUsingDefaultConfig("dev")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://dev.example.com:6443", client.config.Host)
+}
+
+func TestNewClientFromRESTConfig(t *testing.T) {
+	config := &restclient.Config{Host: "https://127.0.0.1:6443"}
+
+	client, err := NewClientFromRESTConfig(config, WithBurst(50))
+	assert.NoError(t, err)
+	assert.Equal(t, "https://127.0.0.1:6443", client.config.Host)
+	assert.Equal(t, 50, client.config.Burst)
+	assert.NotNil(t, client.groupResourceMapper)
+	assert.Equal(t, 0, config.Burst)
+
+	_, err = NewClientFromRESTConfig(nil)
+	assert.ErrorIs(t, err, ErrMissingRESTConfig{})
+}
+
+func TestCreate(t *testing.T) {
+	fakeClient := newTestDynamicClient()
+	client := Client{client: fakeClient}
+	ctx := context.Background()
+
+	// The fake client does not generate names, so we mimic the API server
+	fakeClient.PrependReactor("create", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		obj := action.(k8stesting.CreateAction).GetObject().(*unstructured.Unstructured)
+		if obj.GetName() == "" {
+			obj.SetName(obj.GetGenerateName() + "abcde")
+		}
+		return false, nil, nil
+	})
+
+	obj := NamedObject{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"generateName": "test-",
+			"namespace":    "default",
+		},
+	}
+
+	created, err := client.Create(ResourceConfigMap, obj, metav1.CreateOptions{}, ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "test-abcde", created.GetName())
+
+	_, err = client.GetNamespacedObject(ResourceConfigMap, "test-abcde", "default", ctx)
+	assert.NoError(t, err)
+
+	// Creating an existing object fails
+	_, err = client.Create(ResourceConfigMap, created, metav1.CreateOptions{}, ctx)
+	assert.Error(t, err)
+}
+
+func TestApplyAndGet(t *testing.T) {
+	fakeClient := newTestDynamicClient()
+	client := Client{client: fakeClient}
+	ctx := context.Background()
+
+	fakeClient.PrependReactor("patch", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		obj := newTestConfigMap(action.(k8stesting.PatchAction).GetName())
+		obj.SetUID("1234")
+		obj.SetResourceVersion("1")
+		return true, obj, nil
+	})
+
+	obj, err := NamedObjectFromUnstructured(*newTestConfigMap("test"))
+	assert.NoError(t, err)
+
+	result, err := client.ApplyAndGet(ResourceConfigMap, obj, metav1.ApplyOptions{FieldManager: "test"}, ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "test", result.GetName())
+	assert.Equal(t, "1234", result.GetUID())
+}
@@ -0,0 +1,78 @@
+package kubernetes
+
+import (
+	"context"
+	"io"
+	"iter"
+	"time"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ClientInterface covers all functions of Client. Code depending on this
+// interface instead of *Client can be tested using NewFakeClient.
+type ClientInterface interface {
+	ObjectReader
+
+	ListPage(resource schema.GroupVersionResource, options ListOptions, ctx context.Context) ([]NamedObject, string, error)
+	ListIter(resource schema.GroupVersionResource, options ListOptions, ctx context.Context) iter.Seq2[NamedObject, error]
+	Watch(resource schema.GroupVersionResource, namespace, labelSelector, fieldSelector string, ctx context.Context) (<-chan WatchEvent, error)
+	WaitFor(resource schema.GroupVersionResource, name, namespace string, predicate WaitPredicate, ctx context.Context) (NamedObject, error)
+	NewCache(resources ...schema.GroupVersionResource) *ObjectCache
+
+	Create(resource schema.GroupVersionResource, object NamedObject, options metav1.CreateOptions, ctx context.Context) (NamedObject, error)
+	CreateObject(object NamedObject, options metav1.CreateOptions, ctx context.Context) (NamedObject, error)
+	Update(resource schema.GroupVersionResource, object NamedObject, ctx context.Context) (NamedObject, error)
+	Mutate(resource schema.GroupVersionResource, name, namespace string, mutate func(NamedObject) error, ctx context.Context) (NamedObject, error)
+
+	Apply(resource schema.GroupVersionResource, object NamedObject, options metav1.ApplyOptions, ctx context.Context) error
+	ApplyAndGet(resource schema.GroupVersionResource, object NamedObject, options metav1.ApplyOptions, ctx context.Context) (NamedObject, error)
+	ApplyObject(object NamedObject, options metav1.ApplyOptions, ctx context.Context) error
+	ApplyObjectAndGet(object NamedObject, options metav1.ApplyOptions, ctx context.Context) (NamedObject, error)
+	ApplyAll(objects []NamedObject, options metav1.ApplyOptions, ctx context.Context) ([]ApplyResult, error)
+	ApplyForcingConflicts(resource schema.GroupVersionResource, object NamedObject, options metav1.ApplyOptions, forcePaths []string, ctx context.Context) error
+
+	Patch(resource schema.GroupVersionResource, object NamedObject, patches []PatchOperation, options metav1.PatchOptions, ctx context.Context) error
+	PatchWithType(resource schema.GroupVersionResource, object NamedObject, patchType types.PatchType, patch interface{}, options metav1.PatchOptions, ctx context.Context) error
+	PatchObject(object NamedObject, patches []PatchOperation, options metav1.PatchOptions, ctx context.Context) error
+	PatchObjectWithType(object NamedObject, patchType types.PatchType, patch interface{}, options metav1.PatchOptions, ctx context.Context) error
+
+	DeleteNamespaced(resource schema.GroupVersionResource, name, namespace string, ctx context.Context) error
+	DeleteWithOptions(resource schema.GroupVersionResource, name, namespace string, options metav1.DeleteOptions, ctx context.Context) error
+	DeleteObject(object NamedObject, ctx context.Context) error
+	DeleteObjectWithOptions(object NamedObject, options metav1.DeleteOptions, ctx context.Context) error
+	DeleteAndWait(resource schema.GroupVersionResource, name, namespace string, options metav1.DeleteOptions, ctx context.Context) error
+	DeleteCollection(resource schema.GroupVersionResource, namespace, labelSelector string, options metav1.DeleteOptions, ctx context.Context) error
+
+	GetSubresource(resource schema.GroupVersionResource, name, namespace, subresource string, ctx context.Context) (NamedObject, error)
+	UpdateStatus(resource schema.GroupVersionResource, object NamedObject, options metav1.UpdateOptions, ctx context.Context) (NamedObject, error)
+	PatchStatus(resource schema.GroupVersionResource, object NamedObject, patchType types.PatchType, patch interface{}, options metav1.PatchOptions, ctx context.Context) (NamedObject, error)
+	GetScale(resource schema.GroupVersionResource, name, namespace string, ctx context.Context) (NamedObject, error)
+	SetScale(resource schema.GroupVersionResource, name, namespace string, replicas int32, ctx context.Context) (NamedObject, error)
+	EvictPod(pod NamedObject, options metav1.DeleteOptions, ctx context.Context) error
+
+	StreamPodLogs(pod NamedObject, options corev1.PodLogOptions, ctx context.Context) (io.ReadCloser, error)
+	ExecInPod(pod NamedObject, options ExecOptions, ctx context.Context) error
+	PortForward(pod NamedObject, ports []string, options PortForwardOptions, ctx context.Context) error
+
+	RecordEvent(obj NamedObject, eventType, reason, message string, ctx context.Context) (NamedObject, error)
+	EventsFor(obj NamedObject, ctx context.Context) ([]NamedObject, error)
+	RunWithLeaderElection(leaseName, namespace, identity string, onStartedLeading func(context.Context), onStoppedLeading func(), ctx context.Context) error
+
+	ResolveResource(name string) (ResourceInfo, error)
+	ResolveKind(apiVersion, kind string) (ResourceInfo, error)
+	GVRForObject(obj NamedObject) (ResourceInfo, error)
+	RefreshDiscovery(ctx context.Context) error
+
+	CanI(verb string, resource schema.GroupVersionResource, namespace, name string, ctx context.Context) (bool, error)
+	CanSubject(user string, groups []string, verb string, resource schema.GroupVersionResource, namespace, name string, ctx context.Context) (bool, error)
+	ListPermissions(namespace string, ctx context.Context) (authorizationv1.SubjectRulesReviewStatus, error)
+
+	GetServiceAccountToken(serviceAccountName, namespace string, expiration time.Duration, audiences []string, pod NamedObject, ctx context.Context) (string, error)
+}
+
+var _ ClientInterface = (*Client)(nil)
@@ -0,0 +1,112 @@
+package kubernetes
+
+import (
+	"time"
+
+	restclient "k8s.io/client-go/rest"
+)
+
+// ClientOption configures a Client during creation.
+// Options are passed to the constructors, e.g. NewClientUsingContext.
+type ClientOption func(*clientOptions)
+
+// clientOptions holds the settings collected from all ClientOptions.
+type clientOptions struct {
+	config      *restclient.Config
+	qps         float32
+	burst       int
+	timeout     time.Duration
+	userAgent   string
+	impersonate *restclient.ImpersonationConfig
+}
+
+// WithQPS sets the maximum number of requests per second sent by the client.
+// If not set, the QPS of the config or the client-go default is used.
+func WithQPS(qps float32) ClientOption {
+	return func(o *clientOptions) {
+		o.qps = qps
+	}
+}
+
+// WithBurst sets the maximum number of requests the client may send in a
+// burst, exceeding the QPS limit. If not set, the burst of the config or the
+// client-go default is used.
+func WithBurst(burst int) ClientOption {
+	return func(o *clientOptions) {
+		o.burst = burst
+	}
+}
+
+// WithTimeout sets the maximum time a single request may take. A value of 0
+// disables the timeout, which is the default.
+// The timeout also applies to long-running requests, which are cut off once
+// it has passed. This affects Watch, WaitFor, DeleteAndWait, ObjectCache,
+// RunWithLeaderElection, StreamPodLogs with Follow set, ExecInPod and
+// PortForward. Use a separate client without timeout for these, or limit
+// single calls via their context instead.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(o *clientOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithUserAgent sets the user agent sent with each request.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(o *clientOptions) {
+		o.userAgent = userAgent
+	}
+}
+
+// WithImpersonation lets the client act as a given user and groups.
+// The credentials used by the client require the "impersonate" permission
+// for the given user and groups.
+func WithImpersonation(user string, groups ...string) ClientOption {
+	return func(o *clientOptions) {
+		o.impersonate = &restclient.ImpersonationConfig{
+			UserName: user,
+			Groups:   groups,
+		}
+	}
+}
+
+// WithRESTConfig uses a prebuilt config instead of loading a kubeconfig file
+// or the in-cluster config. The config is copied, so it is not modified by
+// other options.
+func WithRESTConfig(config *restclient.Config) ClientOption {
+	return func(o *clientOptions) {
+		o.config = config
+	}
+}
+
+// newClientOptions collects the settings of all given options.
+func newClientOptions(options []ClientOption) clientOptions {
+	opts := clientOptions{}
+	for _, option := range options {
+		option(&opts)
+	}
+	return opts
+}
+
+// apply returns a copy of config with all options applied.
+func (o clientOptions) apply(config *restclient.Config) *restclient.Config {
+	config = restclient.CopyConfig(config)
+
+	if o.qps > 0 {
+		config.QPS = o.qps
+	}
+	if o.burst > 0 {
+		config.Burst = o.burst
+	}
+
+	if o.timeout > 0 {
+		config.Timeout = o.timeout
+	}
+	if o.userAgent != "" {
+		config.UserAgent = o.userAgent
+	}
+	if o.impersonate != nil {
+		config.Impersonate = *o.impersonate
+	}
+
+	return config
+}
@@ -0,0 +1,57 @@
+package kubernetes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	restclient "k8s.io/client-go/rest"
+)
+
+func TestClientOptions(t *testing.T) {
+	base := &restclient.Config{Host: "https://127.0.0.1:6443"}
+
+	// client-go defaults are kept unless overridden
+	config := newClientOptions(nil).apply(base)
+	assert.Equal(t, float32(0), config.QPS)
+	assert.Equal(t, 0, config.Burst)
+	assert.Equal(t, time.Duration(0), config.Timeout)
+
+	config = newClientOptions([]ClientOption{
+		WithQPS(200),
+		WithBurst(400),
+		WithTimeout(10 * time.Second),
+		WithUserAgent("inventory"),
+		WithImpersonation("jane", "admins", "developers"),
+	}).apply(base)
+
+	assert.Equal(t, float32(200), config.QPS)
+	assert.Equal(t, 400, config.Burst)
+	assert.Equal(t, 10*time.Second, config.Timeout)
+	assert.Equal(t, "inventory", config.UserAgent)
+	assert.Equal(t, "jane", config.Impersonate.UserName)
+	assert.Equal(t, []string{"admins", "developers"}, config.Impersonate.Groups)
+
+	// The original config must not be modified
+	assert.Equal(t, float32(0), base.QPS)
+	assert.Equal(t, "", base.UserAgent)
+
+	// Values set on the config are kept unless overridden
+	config = newClientOptions(nil).apply(&restclient.Config{QPS: 20, Burst: 30})
+	assert.Equal(t, float32(20), config.QPS)
+	assert.Equal(t, 30, config.Burst)
+
+	config = newClientOptions([]ClientOption{WithQPS(40)}).apply(&restclient.Config{QPS: 20, Burst: 30})
+	assert.Equal(t, float32(40), config.QPS)
+	assert.Equal(t, 30, config.Burst)
+}
+
+func TestNewClientWithRESTConfig(t *testing.T) {
+	config := &restclient.Config{Host: "https://127.0.0.1:6443"}
+
+	client, err := NewClientUsingContext("/does/not/exist", "", WithRESTConfig(config), WithQPS(100))
+	assert.NoError(t, err)
+	assert.NotNil(t, client.client)
+	assert.NotNil(t, client.apiClient.corev1)
+	assert.NotNil(t, client.groupResourceMapper)
+}
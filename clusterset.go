@@ -0,0 +1,179 @@
+package kubernetes
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// DefaultClusterParallelism is the number of clusters a ClusterSet talks to
+// concurrently, unless changed via SetParallelism.
+const DefaultClusterParallelism = 10
+
+// ClusterResult holds the outcome of a ClusterSet call for a single cluster.
+type ClusterResult[T any] struct {
+	// Value holds the result of the call. If Err is set, Value is usually the
+	// zero value, but may hold partial results, e.g. for ErrPartialList.
+	Value T
+
+	// Err is set if the call failed for this cluster, or if no client could
+	// be created for this cluster.
+	Err error
+}
+
+// ClusterSet holds clients for multiple clusters, identified by their
+// kubeconfig context name. Calls are sent to all clusters concurrently and
+// results are reported per cluster, so that a single unreachable cluster does
+// not affect the others.
+type ClusterSet struct {
+	clients     map[string]ClientInterface
+	errors      map[string]error
+	parallelism int
+}
+
+// NewClusterSet creates clients for all contexts of a given kubeconfig for
+// which filter returns true. If filter is nil, all contexts are used.
+// The path may contain a list of files, see NewClientUsingContext. If no path
+// is given, the kubeconfig is resolved like in NewClientUsingDefaultConfig.
+// An error is only returned if the kubeconfig cannot be read. Contexts for
+// which no client can be created are reported as an error in every result.
+func NewClusterSet(path string, filter func(context string) bool, options ...ClientOption) (*ClusterSet, error) {
+	contexts, err := GetContextsFromConfig(path)
+	if err != nil {
+		return nil, err
+	}
+
+	clusters := NewClusterSetFromClients(map[string]ClientInterface{})
+	for _, context := range contexts {
+		if filter != nil && !filter(context) {
+			continue
+		}
+
+		var client ClientInterface
+		if path == "" {
+			client, err = NewClientUsingDefaultConfig(context, options...)
+		} else {
+			client, err = NewClientUsingContext(path, context, options...)
+		}
+
+		if err != nil {
+			clusters.errors[context] = err
+			continue
+		}
+		clusters.clients[context] = client
+	}
+
+	return clusters, nil
+}
+
+// NewClusterSetFromClients creates a ClusterSet from existing clients, keyed
+// by context name. Use NewFakeClient to create clients for tests.
+func NewClusterSetFromClients(clients map[string]ClientInterface) *ClusterSet {
+	clusters := &ClusterSet{
+		clients:     make(map[string]ClientInterface, len(clients)),
+		errors:      map[string]error{},
+		parallelism: DefaultClusterParallelism,
+	}
+	for context, client := range clients {
+		clusters.clients[context] = client
+	}
+	return clusters
+}
+
+// SetParallelism sets the number of clusters called concurrently.
+// Values smaller than 1 are treated as 1.
+func (cs *ClusterSet) SetParallelism(parallelism int) {
+	cs.parallelism = max(parallelism, 1)
+}
+
+// Contexts returns the sorted names of all contexts in this set, including
+// contexts for which no client could be created.
+func (cs *ClusterSet) Contexts() []string {
+	contexts := make([]string, 0, len(cs.clients)+len(cs.errors))
+	for context := range cs.clients {
+		contexts = append(contexts, context)
+	}
+	for context := range cs.errors {
+		contexts = append(contexts, context)
+	}
+	sort.Strings(contexts)
+	return contexts
+}
+
+// Client returns the client of a given context. Returns false if the context
+// is not part of this set or no client could be created for it.
+func (cs *ClusterSet) Client(context string) (ClientInterface, bool) {
+	client, ok := cs.clients[context]
+	return client, ok
+}
+
+// List returns a list of all objects for a given type from all clusters.
+// See ListAllObjectsInNamespace for the parameters.
+func (cs *ClusterSet) List(resource schema.GroupVersionResource, namespace, labelSelector, fieldSelector string, ctx context.Context) map[string]ClusterResult[[]NamedObject] {
+	return fanOut(cs, ctx, func(client ClientInterface) ([]NamedObject, error) {
+		return client.ListAllObjectsInNamespace(resource, namespace, labelSelector, fieldSelector, ctx)
+	})
+}
+
+// Get returns a specific kubernetes object from all clusters.
+// If an empty namespace is given, the object will be treated as a cluster-wide resource.
+func (cs *ClusterSet) Get(resource schema.GroupVersionResource, name, namespace string, ctx context.Context) map[string]ClusterResult[NamedObject] {
+	return fanOut(cs, ctx, func(client ClientInterface) (NamedObject, error) {
+		return client.GetNamespacedObject(resource, name, namespace, ctx)
+	})
+}
+
+// Apply creates or updates a given kubernetes object on all clusters and
+// returns the object reported by each cluster. See ApplyAndGet.
+func (cs *ClusterSet) Apply(resource schema.GroupVersionResource, object NamedObject, options metav1.ApplyOptions, ctx context.Context) map[string]ClusterResult[NamedObject] {
+	return fanOut(cs, ctx, func(client ClientInterface) (NamedObject, error) {
+		return client.ApplyAndGet(resource, object, options, ctx)
+	})
+}
+
+// fanOut calls a given function for all clients of a ClusterSet, running at
+// most cs.parallelism calls concurrently. Clusters that have not been called
+// before ctx is done report the context error.
+func fanOut[T any](cs *ClusterSet, ctx context.Context, call func(ClientInterface) (T, error)) map[string]ClusterResult[T] {
+	var (
+		results = make(map[string]ClusterResult[T], len(cs.clients)+len(cs.errors))
+		lock    sync.Mutex
+		wg      sync.WaitGroup
+		slots   = make(chan struct{}, max(cs.parallelism, 1))
+	)
+
+	for context, err := range cs.errors {
+		results[context] = ClusterResult[T]{Err: err}
+	}
+
+	for context, client := range cs.clients {
+		select {
+		case <-ctx.Done():
+			lock.Lock()
+			results[context] = ClusterResult[T]{Err: ctx.Err()}
+			lock.Unlock()
+			continue
+		case slots <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+
+			value, err := call(client)
+
+			lock.Lock()
+			defer lock.Unlock()
+			results[context] = ClusterResult[T]{Value: value, Err: err}
+		}()
+	}
+
+	wg.Wait()
+	return results
+}
@@ -0,0 +1,99 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestClusterSet(t *testing.T) {
+	unreachable := newTestDynamicClient()
+	unreachable.PrependReactor("*", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("connection refused")
+	})
+
+	clusters := NewClusterSetFromClients(map[string]ClientInterface{
+		"a":           &Client{client: newTestDynamicClient(newTestConfigMap("test"))},
+		"b":           &Client{client: newTestDynamicClient(newTestConfigMap("test"), newTestConfigMap("other"))},
+		"unreachable": &Client{client: unreachable},
+	})
+	clusters.errors["broken"] = errors.New("invalid context")
+	ctx := context.Background()
+
+	assert.Equal(t, []string{"a", "b", "broken", "unreachable"}, clusters.Contexts())
+
+	lists := clusters.List(ResourceConfigMap, "default", "", "", ctx)
+	assert.Len(t, lists, 4)
+	assert.NoError(t, lists["a"].Err)
+	assert.Len(t, lists["a"].Value, 1)
+	assert.NoError(t, lists["b"].Err)
+	assert.Len(t, lists["b"].Value, 2)
+	assert.ErrorContains(t, lists["unreachable"].Err, "connection refused")
+	assert.ErrorContains(t, lists["broken"].Err, "invalid context")
+
+	objects := clusters.Get(ResourceConfigMap, "other", "default", ctx)
+	assert.Len(t, objects, 4)
+	assert.Error(t, objects["a"].Err)
+	assert.NoError(t, objects["b"].Err)
+	assert.Equal(t, "other", objects["b"].Value.GetName())
+	assert.Error(t, objects["unreachable"].Err)
+}
+
+func TestClusterSetParallelism(t *testing.T) {
+	var running, maxRunning atomic.Int32
+
+	clients := map[string]ClientInterface{}
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		fakeClient := newTestDynamicClient()
+		fakeClient.PrependReactor("list", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			current := running.Add(1)
+			defer running.Add(-1)
+			for {
+				highest := maxRunning.Load()
+				if current <= highest || maxRunning.CompareAndSwap(highest, current) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			return false, nil, nil
+		})
+		clients[name] = &Client{client: fakeClient}
+	}
+
+	clusters := NewClusterSetFromClients(clients)
+	clusters.SetParallelism(2)
+
+	results := clusters.List(ResourceConfigMap, "default", "", "", context.Background())
+	assert.Len(t, results, 5)
+	for _, result := range results {
+		assert.NoError(t, result.Err)
+	}
+	assert.Equal(t, int32(2), maxRunning.Load())
+}
+
+func TestNewClusterSet(t *testing.T) {
+	dev, prod := writeTestKubeconfig(t, "dev"), writeTestKubeconfig(t, "prod")
+	kubeconfig := dev + string(os.PathListSeparator) + prod
+
+	clusters, err := NewClusterSet(kubeconfig, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"dev", "prod"}, clusters.Contexts())
+
+	clusters, err = NewClusterSet(kubeconfig, func(context string) bool {
+		return context == "prod"
+	}, WithQPS(100))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"prod"}, clusters.Contexts())
+
+	client, ok := clusters.Client("prod")
+	assert.True(t, ok)
+	assert.Equal(t, "https://prod.example.com:6443", client.(*Client).config.Host)
+	assert.Equal(t, float32(100), client.(*Client).config.QPS)
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"net/http"
 	_ "net/http/pprof"
 	"os"
@@ -8,40 +9,10 @@ import (
 	"time"
 
 	"github.com/rs/zerolog/log"
-	kubernetes "github.com/trivago/go-kubernetes/v3"
+	kubernetes "github.com/trivago/go-kubernetes/v4"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
-func connectToKubernetes(kubeConfigPath string) (map[string]*kubernetes.Client, error) {
-	clusters := make(map[string]*kubernetes.Client)
-
-	log.Debug().Msgf("getting config from %s", kubeConfigPath)
-
-	contexts, err := kubernetes.GetContextsFromConfig(kubeConfigPath)
-	if err != nil {
-		log.Error().Msg("failed to read contexts from kubeconfig")
-		return clusters, err
-	}
-
-	numContexts := 0
-
-	for _, context := range contexts {
-		numContexts++
-		if numContexts > 3 {
-			break
-		}
-		remoteClient, err := kubernetes.NewClientUsingContext(kubeConfigPath, context)
-		if err != nil {
-			log.Error().Msgf("failed to create remote kubernetes client for context %s", context)
-			continue
-		}
-		log.Debug().Msgf("created remote kubernetes client for context %s", context)
-		clusters[context] = remoteClient
-	}
-
-	return clusters, nil
-}
-
 func main() {
 	// Start the CPU profiler
 	if os.Getenv("PROFILE_OUT") == "file" {
@@ -67,15 +38,11 @@ func main() {
 		}()
 	}
 
-	// Set up contexts
-	kubeConfigPath := os.Getenv("KUBECONFIG")
-	if kubeConfigPath == "" {
-		kubeConfigPath = os.ExpandEnv("$HOME/.kube/config")
-	}
-
-	clusters, err := connectToKubernetes(kubeConfigPath)
+	// Set up contexts. An empty path resolves $KUBECONFIG or ~/.kube/config
+	// like kubectl.
+	clusters, err := kubernetes.NewClusterSet("", nil)
 	if err != nil {
-		log.Fatal().Msg("failed to connect to any context")
+		log.Fatal().Msg("failed to read contexts from kubeconfig")
 		return
 	}
 
@@ -86,12 +53,13 @@ func main() {
 		Resource: "namespaces",
 	}
 
-	for context, client := range clusters {
-		namespaces, err := client.ListAllObjects(namespaceGVR, "", "")
-		if err != nil {
-			log.Error().Msgf("failed to list namespaces in context %s", context)
+	results := clusters.List(namespaceGVR, "", "", "", context.Background())
+	for _, context := range clusters.Contexts() {
+		result := results[context]
+		if result.Err != nil {
+			log.Error().Err(result.Err).Msgf("failed to list namespaces in context %s", context)
 			continue
 		}
-		log.Info().Msgf("namespaces in context %s: %v", context, namespaces)
+		log.Info().Msgf("namespaces in context %s: %v", context, result.Value)
 	}
 }
@@ -0,0 +1,68 @@
+package kubernetes
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// DeleteAndWait removes a specific kubernetes object like DeleteWithOptions
+// and waits until the object has actually been removed. This includes waiting
+// for all finalizers to complete, as well as for dependents when using
+// metav1.DeletePropagationForeground.
+// If the object does not exist, nil is returned. If ctx is done before the
+// object is gone, the context error is returned.
+// If an empty namespace is given, the object will be treated as a cluster-wide resource.
+func (k8s *Client) DeleteAndWait(resource schema.GroupVersionResource, name, namespace string, options metav1.DeleteOptions, ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// The watch is started before deleting, so the deletion cannot be missed.
+	fieldSelector := fields.OneTermEqualSelector("metadata.name", name).String()
+	events, err := k8s.Watch(resource, namespace, "", fieldSelector, ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := k8s.DeleteWithOptions(resource, name, namespace, options, ctx); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	for event := range events {
+		switch {
+		case event.Type == watch.Error:
+			return errors.Wrapf(event.Err, "failed to wait for deletion of %s", objectIdentifier(name, namespace))
+		case event.Type == watch.Deleted && event.Object.GetName() == name:
+			return nil
+		}
+	}
+
+	if ctx.Err() != nil {
+		return errors.Wrapf(ctx.Err(), "failed to wait for deletion of %s", objectIdentifier(name, namespace))
+	}
+	return nil
+}
+
+// DeleteCollection removes all objects of a given type matching a given label
+// selector. An empty selector removes all objects of that type.
+// See DeleteWithOptions for the available options.
+// If an empty namespace is given, the objects will be treated as cluster-wide resources.
+func (k8s *Client) DeleteCollection(resource schema.GroupVersionResource, namespace, labelSelector string, options metav1.DeleteOptions, ctx context.Context) error {
+	listOptions := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	if err := k8s.getResourceHandle(resource, namespace).DeleteCollection(ctx, options, listOptions); err != nil {
+		return errors.Wrapf(err, "failed to trigger delete for %s matching %q", resource.String(), labelSelector)
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestDeleteWithOptions(t *testing.T) {
+	fakeClient := newTestDynamicClient(newTestConfigMap("test"))
+	client := Client{client: fakeClient}
+	ctx := context.Background()
+
+	policy := metav1.DeletePropagationForeground
+	options := metav1.DeleteOptions{
+		PropagationPolicy: &policy,
+		Preconditions:     metav1.NewUIDPreconditions("1234"),
+	}
+
+	assert.NoError(t, client.DeleteWithOptions(ResourceConfigMap, "test", "default", options, ctx))
+
+	actions := fakeClient.Actions()
+	assert.Len(t, actions, 1)
+	deleteAction := actions[0].(k8stesting.DeleteAction)
+	assert.Equal(t, options, deleteAction.GetDeleteOptions())
+}
+
+func TestDeleteAndWait(t *testing.T) {
+	fakeClient := newTestDynamicClient(newTestConfigMap("test"), newTestConfigMap("finalized"))
+	client := Client{client: fakeClient}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	assert.NoError(t, client.DeleteAndWait(ResourceConfigMap, "test", "default", metav1.DeleteOptions{}, ctx))
+
+	// Deleting a missing object returns immediately
+	assert.NoError(t, client.DeleteAndWait(ResourceConfigMap, "test", "default", metav1.DeleteOptions{}, ctx))
+
+	// Mimic a finalizer by keeping the object until it is removed later on
+	fakeClient.PrependReactor("delete", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, nil
+	})
+
+	waitCtx, waitCancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer waitCancel()
+	err := client.DeleteAndWait(ResourceConfigMap, "finalized", "default", metav1.DeleteOptions{}, waitCtx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		_ = fakeClient.Tracker().Delete(ResourceConfigMap, "default", "finalized")
+	}()
+	assert.NoError(t, client.DeleteAndWait(ResourceConfigMap, "finalized", "default", metav1.DeleteOptions{}, ctx))
+}
+
+func TestDeleteCollection(t *testing.T) {
+	fakeClient := newTestDynamicClient()
+	client := Client{client: fakeClient}
+	ctx := context.Background()
+
+	fakeClient.PrependReactor("delete-collection", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, nil
+	})
+
+	assert.NoError(t, client.DeleteCollection(ResourceConfigMap, "default", "app=test", metav1.DeleteOptions{}, ctx))
+
+	actions := fakeClient.Actions()
+	assert.Len(t, actions, 1)
+	listRestrictions := actions[0].(k8stesting.DeleteCollectionAction).GetListRestrictions()
+	assert.Equal(t, "app=test", listRestrictions.Labels.String())
+}
@@ -1,6 +1,9 @@
 package kubernetes
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // ErrNotFound is returned when a requested path key or array index does not exist
 // in a NamedObject. This error is used during path traversal operations when:
@@ -187,3 +190,213 @@ type ErrParseError string
 func (e ErrParseError) Error() string {
 	return string(e)
 }
+
+// ErrNotCached is returned when an ObjectCache is queried for a resource that
+// was not passed to Client.NewCache. Only resources registered during cache
+// creation are backed by an informer.
+//
+// The error string contains the requested GroupVersionResource.
+type ErrNotCached string
+
+func (e ErrNotCached) Error() string {
+	return fmt.Sprintf("Resource is not cached: %s", string(e))
+}
+
+// ErrCacheNotSynced is returned by ObjectCache.WaitForSync when the context is
+// done before the informer of a resource finished its initial list.
+//
+// The error string contains the GroupVersionResource that failed to sync.
+type ErrCacheNotSynced string
+
+func (e ErrCacheNotSynced) Error() string {
+	return fmt.Sprintf("Cache did not sync: %s", string(e))
+}
+
+// ErrListItem is returned for a single item of a list call that could not be
+// converted into a NamedObject. The item is still part of the returned list.
+//
+// Index denotes the position of the item in the list response, Err holds the
+// conversion error, e.g. ErrMissingName.
+type ErrListItem struct {
+	Index int
+	Err   error
+}
+
+func (e ErrListItem) Error() string {
+	return fmt.Sprintf("Failed to parse list item %d: %v", e.Index, e.Err)
+}
+
+func (e ErrListItem) Unwrap() error {
+	return e.Err
+}
+
+// ErrPartialList is returned by list calls when one or more items could not
+// be converted into a NamedObject. The list itself is still returned, so
+// callers can decide to ignore individual items.
+//
+// Each failed item is reported as a separate ErrListItem.
+type ErrPartialList []ErrListItem
+
+func (e ErrPartialList) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, itemErr := range e {
+		messages = append(messages, itemErr.Error())
+	}
+	return fmt.Sprintf("Failed to parse %d list items: %s", len(e), strings.Join(messages, "; "))
+}
+
+func (e ErrPartialList) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, itemErr := range e {
+		errs = append(errs, itemErr)
+	}
+	return errs
+}
+
+// ErrInvalidManifest is returned when a document of a manifest file cannot be
+// parsed or does not contain a valid kubernetes object. This occurs in
+// LoadManifests and LoadManifestsFromDir when:
+//   - A document is neither valid YAML nor JSON
+//   - A document or list item is not a key/value object
+//   - An object does not have a name or generateName set
+//
+// Path holds the file the document was read from, which is empty when reading
+// from a stream. Index denotes the position of the document in the file.
+type ErrInvalidManifest struct {
+	Path  string
+	Index int
+	Err   error
+}
+
+func (e ErrInvalidManifest) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("Invalid manifest in document %d: %v", e.Index, e.Err)
+	}
+	return fmt.Sprintf("Invalid manifest in %s, document %d: %v", e.Path, e.Index, e.Err)
+}
+
+func (e ErrInvalidManifest) Unwrap() error {
+	return e.Err
+}
+
+// ErrApplyFailed is returned by ApplyAll when one or more objects could not be
+// applied. The per-object errors are available in the returned ApplyResult
+// list.
+type ErrApplyFailed struct {
+	Failed int
+	Total  int
+}
+
+func (e ErrApplyFailed) Error() string {
+	return fmt.Sprintf("Failed to apply %d of %d objects", e.Failed, e.Total)
+}
+
+// ApplyConflict describes a single field conflict reported by a server-side
+// apply request.
+type ApplyConflict struct {
+	// Path holds the conflicting field in server-side apply notation,
+	// e.g. ".spec.replicas" or ".spec.containers[name=\"app\"].image".
+	Path string
+
+	// Manager holds the name of the field manager owning the field.
+	Manager string
+
+	// Message holds the message reported by the API server.
+	Message string
+}
+
+// ErrApplyConflict is returned by Apply and its variants when a server-side
+// apply request conflicts with fields owned by other field managers. Each
+// conflicting field is listed in Conflicts.
+// Use ApplyForcingConflicts to take over ownership of specific fields.
+//
+// The original API error is available through errors.Unwrap.
+type ErrApplyConflict struct {
+	Object    string
+	Conflicts []ApplyConflict
+	Err       error
+}
+
+func (e ErrApplyConflict) Error() string {
+	conflicts := make([]string, 0, len(e.Conflicts))
+	for _, c := range e.Conflicts {
+		conflicts = append(conflicts, fmt.Sprintf("%s (managed by %s)", c.Path, c.Manager))
+	}
+	return fmt.Sprintf("Apply conflicts for %s: %s", e.Object, strings.Join(conflicts, ", "))
+}
+
+func (e ErrApplyConflict) Unwrap() error {
+	return e.Err
+}
+
+// ErrInvalidPatch is returned when a patch passed to PatchWithType does not
+// match the requested patch type. This occurs when:
+//   - A []PatchOperation is passed for a patch type other than PatchJSON
+//   - An object fragment is passed for PatchJSON
+//   - The patch is of an unsupported type
+//
+// The error string contains the patch type and the type of the given patch.
+type ErrInvalidPatch string
+
+func (e ErrInvalidPatch) Error() string {
+	return fmt.Sprintf("Invalid patch: %s", string(e))
+}
+
+// ErrEvictionBlocked is returned by EvictPod when the API server rejects an
+// eviction because it would violate a PodDisruptionBudget. The eviction can
+// be retried once enough pods covered by the budget are available again.
+//
+// The original API error is available through errors.Unwrap.
+type ErrEvictionBlocked struct {
+	Pod string
+	Err error
+}
+
+func (e ErrEvictionBlocked) Error() string {
+	return fmt.Sprintf("Eviction of pod %s blocked by disruption budget: %v", e.Pod, e.Err)
+}
+
+func (e ErrEvictionBlocked) Unwrap() error {
+	return e.Err
+}
+
+// ErrMissingRESTConfig is returned by functions that require a direct
+// connection to the API server, like ExecInPod or PortForward, when the
+// client has not been created from a REST config, e.g. a FakeClient.
+// It is also returned by NewClientFromRESTConfig if no config is given.
+type ErrMissingRESTConfig struct{}
+
+func (e ErrMissingRESTConfig) Error() string {
+	return "Client has no REST config"
+}
+
+// ErrLeadershipLost is returned by RunWithLeaderElection when the lease could
+// not be renewed in time and another candidate may have taken over.
+//
+// The error string contains the namespace and name of the lease.
+type ErrLeadershipLost string
+
+func (e ErrLeadershipLost) Error() string {
+	return fmt.Sprintf("Leadership lost: %s", string(e))
+}
+
+// ErrRolloutFailed is returned by RolloutComplete when a rollout cannot
+// complete anymore, e.g. because a Deployment has exceeded its progress
+// deadline. WaitFor stops waiting when this error is returned.
+type ErrRolloutFailed struct {
+	Object  string
+	Reason  string
+	Message string
+}
+
+func (e ErrRolloutFailed) Error() string {
+	return fmt.Sprintf("Rollout of %s failed: %s: %s", e.Object, e.Reason, e.Message)
+}
+
+// ErrMissingNamespace is returned by functions that cannot operate across all
+// namespaces, like ListPermissions, when no namespace is given.
+type ErrMissingNamespace struct{}
+
+func (e ErrMissingNamespace) Error() string {
+	return "No namespace given"
+}
@@ -0,0 +1,131 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// EventTypeNormal marks events reporting regular operations.
+	EventTypeNormal = corev1.EventTypeNormal
+
+	// EventTypeWarning marks events reporting unexpected or failed operations.
+	EventTypeWarning = corev1.EventTypeWarning
+
+	// EventReportingController is the controller name reported with events
+	// created by RecordEvent.
+	EventReportingController = "github.com/trivago/go-kubernetes"
+)
+
+// RecordEvent creates an events.k8s.io/v1 Event for a given object. The event
+// refers to the object by kind, apiVersion, name, namespace and uid (the
+// "regarding" field, called "involvedObject" in core/v1 events).
+// The event type should be EventTypeNormal or EventTypeWarning. The reason is
+// a short, CamelCase identifier and is also used as the event's action.
+// Events are created in the object's namespace. Events for cluster-wide
+// objects are created in the default namespace.
+// The hostname is reported as the instance creating the event.
+func (k8s *Client) RecordEvent(obj NamedObject, eventType, reason, message string, ctx context.Context) (NamedObject, error) {
+	instance, err := os.Hostname()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to determine reporting instance for event")
+	}
+
+	now := time.Now()
+	namespace := eventNamespace(obj)
+
+	event := &eventsv1.Event{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: eventsv1.SchemeGroupVersion.String(),
+			Kind:       "Event",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s.%x", obj.GetName(), now.UnixNano()),
+			Namespace: namespace,
+		},
+		EventTime:           metav1.NewMicroTime(now),
+		ReportingController: EventReportingController,
+		ReportingInstance:   instance,
+		Action:              reason,
+		Reason:              reason,
+		Regarding: corev1.ObjectReference{
+			APIVersion: obj.GetVersion(),
+			Kind:       obj.GetKind(),
+			Name:       obj.GetName(),
+			Namespace:  obj.GetNamespace(),
+			UID:        types.UID(obj.GetUID()),
+		},
+		Note: message,
+		Type: eventType,
+	}
+
+	rawEvent, err := runtime.DefaultUnstructuredConverter.ToUnstructured(event)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to convert event for %s", objectIdentifier(obj.GetName(), obj.GetNamespace()))
+	}
+
+	return k8s.create(ResourceEvent, namespace, rawEvent, metav1.CreateOptions{}, ctx)
+}
+
+// EventsFor returns all events.k8s.io/v1 Events regarding a given object.
+// Events are matched by the object's kind, name and, if set, uid.
+// Events for cluster-wide objects are expected in the default namespace, see
+// RecordEvent.
+func (k8s *Client) EventsFor(obj NamedObject, ctx context.Context) ([]NamedObject, error) {
+	selectors := []string{
+		"regarding.kind=" + obj.GetKind(),
+		"regarding.name=" + obj.GetName(),
+	}
+	if uid := obj.GetUID(); uid != "" {
+		selectors = append(selectors, "regarding.uid="+uid)
+	}
+
+	events, err := k8s.ListAllObjectsInNamespace(ResourceEvent, eventNamespace(obj), "", strings.Join(selectors, ","), ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Filter again, as not all clients, e.g. FakeClient, support field selectors
+	matching := make([]NamedObject, 0, len(events))
+	for _, event := range events {
+		if isEventRegarding(event, obj) {
+			matching = append(matching, event)
+		}
+	}
+
+	return matching, nil
+}
+
+// eventNamespace returns the namespace events for a given object are stored in.
+func eventNamespace(obj NamedObject) string {
+	if namespace := obj.GetNamespace(); namespace != "" {
+		return namespace
+	}
+	return metav1.NamespaceDefault
+}
+
+// isEventRegarding returns true if a given event refers to a given object.
+func isEventRegarding(event, obj NamedObject) bool {
+	regarding, err := event.GetSection(Path{"regarding"})
+	if err != nil {
+		return false
+	}
+
+	if regarding["kind"] != obj.GetKind() || regarding["name"] != obj.GetName() {
+		return false
+	}
+	if uid := obj.GetUID(); uid != "" && regarding["uid"] != uid {
+		return false
+	}
+	return true
+}
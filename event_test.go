@@ -0,0 +1,81 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordEvent(t *testing.T) {
+	client := MustNewFakeClient()
+	ctx := context.Background()
+
+	deployment := newTestObject("apps/v1", "Deployment", "app")
+	assert.NoError(t, deployment.SetNamespace("test"))
+	assert.NoError(t, deployment.Set(Path{"metadata", "uid"}, "1234"))
+
+	event, err := client.RecordEvent(deployment, EventTypeWarning, "Rejected", "denied by policy", ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "test", event.GetNamespace())
+	assert.True(t, event.IsOfKind("Event", "events.k8s.io/v1"))
+
+	regarding, err := event.GetSection(Path{"regarding"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"name":       "app",
+		"namespace":  "test",
+		"uid":        "1234",
+	}, regarding)
+
+	for path, expected := range map[string]string{
+		"type":                "Warning",
+		"reason":              "Rejected",
+		"action":              "Rejected",
+		"note":                "denied by policy",
+		"reportingController": EventReportingController,
+	} {
+		value, err := event.GetString(Path{path})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, path)
+	}
+	assert.True(t, event.Has(Path{"eventTime"}))
+
+	// Events for cluster-wide objects are stored in the default namespace
+	event, err = client.RecordEvent(newTestObject("v1", "Node", "node"), EventTypeNormal, "Drained", "", ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "default", event.GetNamespace())
+}
+
+func TestEventsFor(t *testing.T) {
+	client := MustNewFakeClient()
+	ctx := context.Background()
+
+	first := newTestObject("v1", "ConfigMap", "config")
+	second := newTestObject("v1", "ConfigMap", "other")
+	assert.NoError(t, first.SetNamespace("default"))
+	assert.NoError(t, second.SetNamespace("default"))
+
+	_, err := client.RecordEvent(first, EventTypeNormal, "Created", "first", ctx)
+	assert.NoError(t, err)
+	_, err = client.RecordEvent(first, EventTypeNormal, "Updated", "second", ctx)
+	assert.NoError(t, err)
+	_, err = client.RecordEvent(second, EventTypeNormal, "Created", "other", ctx)
+	assert.NoError(t, err)
+
+	events, err := client.EventsFor(first, ctx)
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+
+	// Objects with a uid only match events with the same uid
+	assert.NoError(t, first.Set(Path{"metadata", "uid"}, "1234"))
+	events, err = client.EventsFor(first, ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+
+	list := client.Actions()[len(client.Actions())-1]
+	assert.Equal(t, "list", list.GetVerb())
+	assert.Equal(t, ResourceEvent, list.GetResource())
+}
@@ -0,0 +1,420 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/discovery/cached/memory"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubernetesfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/restmapper"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// fakeResource describes a resource known to a FakeClient.
+type fakeResource struct {
+	ResourceInfo
+	shortNames []string
+}
+
+// fakeResources lists the resources known to a FakeClient without seeding.
+var fakeResources = []fakeResource{
+	newFakeResource("", "v1", "ConfigMap", "configmaps", true, "cm"),
+	newFakeResource("", "v1", "Endpoints", "endpoints", true, "ep"),
+	newFakeResource("", "v1", "Event", "events", true, "ev"),
+	newFakeResource("", "v1", "LimitRange", "limitranges", true, "limits"),
+	newFakeResource("", "v1", "Namespace", "namespaces", false, "ns"),
+	newFakeResource("", "v1", "Node", "nodes", false, "no"),
+	newFakeResource("", "v1", "PersistentVolume", "persistentvolumes", false, "pv"),
+	newFakeResource("", "v1", "PersistentVolumeClaim", "persistentvolumeclaims", true, "pvc"),
+	newFakeResource("", "v1", "Pod", "pods", true, "po"),
+	newFakeResource("", "v1", "ReplicationController", "replicationcontrollers", true, "rc"),
+	newFakeResource("", "v1", "ResourceQuota", "resourcequotas", true, "quota"),
+	newFakeResource("", "v1", "Secret", "secrets", true),
+	newFakeResource("", "v1", "Service", "services", true, "svc"),
+	newFakeResource("", "v1", "ServiceAccount", "serviceaccounts", true, "sa"),
+	newFakeResource("apiextensions.k8s.io", "v1", "CustomResourceDefinition", "customresourcedefinitions", false, "crd", "crds"),
+	newFakeResource("apps", "v1", "DaemonSet", "daemonsets", true, "ds"),
+	newFakeResource("apps", "v1", "Deployment", "deployments", true, "deploy"),
+	newFakeResource("apps", "v1", "ReplicaSet", "replicasets", true, "rs"),
+	newFakeResource("apps", "v1", "StatefulSet", "statefulsets", true, "sts"),
+	newFakeResource("autoscaling", "v2", "HorizontalPodAutoscaler", "horizontalpodautoscalers", true, "hpa"),
+	newFakeResource("batch", "v1", "CronJob", "cronjobs", true, "cj"),
+	newFakeResource("batch", "v1", "Job", "jobs", true),
+	newFakeResource("coordination.k8s.io", "v1", "Lease", "leases", true),
+	newFakeResource("events.k8s.io", "v1", "Event", "events", true, "ev"),
+	newFakeResource("networking.k8s.io", "v1", "Ingress", "ingresses", true, "ing"),
+	newFakeResource("networking.k8s.io", "v1", "IngressClass", "ingressclasses", false),
+	newFakeResource("networking.k8s.io", "v1", "NetworkPolicy", "networkpolicies", true, "netpol"),
+	newFakeResource("policy", "v1", "PodDisruptionBudget", "poddisruptionbudgets", true, "pdb"),
+	newFakeResource("rbac.authorization.k8s.io", "v1", "ClusterRole", "clusterroles", false),
+	newFakeResource("rbac.authorization.k8s.io", "v1", "ClusterRoleBinding", "clusterrolebindings", false),
+	newFakeResource("rbac.authorization.k8s.io", "v1", "Role", "roles", true),
+	newFakeResource("rbac.authorization.k8s.io", "v1", "RoleBinding", "rolebindings", true),
+	newFakeResource("scheduling.k8s.io", "v1", "PriorityClass", "priorityclasses", false, "pc"),
+	newFakeResource("storage.k8s.io", "v1", "StorageClass", "storageclasses", false, "sc"),
+}
+
+// FakeClient is an in-memory Client for unit tests. It implements
+// ClientInterface by embedding a Client that is backed by the client-go fake
+// clients and a static RESTMapper.
+// All requests are recorded and can be inspected via Actions. Errors can be
+// injected per verb and resource via InjectError.
+type FakeClient struct {
+	*Client
+
+	dynamicClient *dynamicfake.FakeDynamicClient
+	clientset     *kubernetesfake.Clientset
+	mapper        *meta.DefaultRESTMapper
+
+	lock    sync.Mutex
+	actions []k8stesting.Action
+	errors  map[fakeErrorKey]error
+}
+
+// fakeErrorKey identifies the requests an injected error applies to.
+type fakeErrorKey struct {
+	verb     string
+	resource string
+}
+
+var _ ClientInterface = (*FakeClient)(nil)
+
+// NewFakeClient creates a FakeClient seeded with the given objects.
+// Common built-in resources are known to the client. The resources of seeded
+// objects with other kinds, e.g. custom resources, are registered based on
+// their kind. Such objects are treated as namespaced if they have a
+// namespace set. Namespaced objects without a namespace are stored in the
+// default namespace.
+// Objects of built-in kinds are also added to the typed clients used by
+// functions like EvictPod or RunWithLeaderElection. Changes are not
+// synchronized between both.
+// Server-side apply is emulated by creating missing objects and merging the
+// applied fields into existing ones. Fields omitted from an applied object are
+// kept and field ownership is not tracked, so apply conflicts never occur.
+// An error is returned if an object cannot be added, e.g. because an object
+// with the same name has been passed before.
+func NewFakeClient(objects ...NamedObject) (*FakeClient, error) {
+	fake := &FakeClient{
+		mapper: meta.NewDefaultRESTMapper(nil),
+		errors: map[fakeErrorKey]error{},
+	}
+
+	resources := append([]fakeResource{}, fakeResources...)
+	for _, obj := range objects {
+		resources = append(resources, guessFakeResource(obj))
+	}
+
+	listKinds := map[schema.GroupVersionResource]string{}
+	apiResources := map[string][]metav1.APIResource{}
+	for _, info := range resources {
+		if _, err := fake.mapper.RESTMapping(info.Kind.GroupKind(), info.Kind.Version); err == nil {
+			continue
+		}
+
+		scope := meta.RESTScopeRoot
+		if info.Namespaced {
+			scope = meta.RESTScopeNamespace
+		}
+		fake.mapper.AddSpecific(info.Kind, info.Resource, info.Resource.GroupVersion().WithResource(strings.ToLower(info.Kind.Kind)), scope)
+		listKinds[info.Resource] = info.Kind.Kind + "List"
+
+		groupVersion := info.Resource.GroupVersion().String()
+		apiResources[groupVersion] = append(apiResources[groupVersion], metav1.APIResource{
+			Name:       info.Resource.Resource,
+			Kind:       info.Kind.Kind,
+			Namespaced: info.Namespaced,
+			ShortNames: info.shortNames,
+			Verbs:      metav1.Verbs{"create", "delete", "deletecollection", "get", "list", "patch", "update", "watch"},
+		})
+	}
+
+	fake.dynamicClient = dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds)
+	fake.dynamicClient.PrependReactor("patch", "*", fake.reactApply)
+	fake.dynamicClient.PrependReactor("*", "*", fake.react)
+	fake.dynamicClient.PrependWatchReactor("*", fake.reactWatch)
+
+	fake.clientset = kubernetesfake.NewClientset()
+	fake.clientset.PrependReactor("*", "*", fake.react)
+	fake.clientset.PrependWatchReactor("*", fake.reactWatch)
+
+	discoveryClient := &fakediscovery.FakeDiscovery{Fake: &k8stesting.Fake{}}
+	for groupVersion, list := range apiResources {
+		discoveryClient.Resources = append(discoveryClient.Resources, &metav1.APIResourceList{
+			GroupVersion: groupVersion,
+			APIResources: list,
+		})
+	}
+	sort.Slice(discoveryClient.Resources, func(i, j int) bool {
+		return discoveryClient.Resources[i].GroupVersion < discoveryClient.Resources[j].GroupVersion
+	})
+
+	cachedDiscovery := memory.NewMemCacheClient(discoveryClient)
+	fake.Client = &Client{
+		apiClient: typedClients{
+			corev1:          fake.clientset.CoreV1(),
+			coordinationv1:  fake.clientset.CoordinationV1(),
+			authorizationv1: fake.clientset.AuthorizationV1(),
+		},
+		client:              fake.dynamicClient,
+		discoveryClient:     cachedDiscovery,
+		groupResourceMapper: restmapper.NewShortcutExpander(fake.mapper, cachedDiscovery, nil),
+	}
+
+	if err := fake.AddObjects(objects...); err != nil {
+		return nil, err
+	}
+
+	return fake, nil
+}
+
+// MustNewFakeClient creates a FakeClient like NewFakeClient, but panics if an
+// object cannot be added. It is meant for tests with static seed objects.
+func MustNewFakeClient(objects ...NamedObject) *FakeClient {
+	fake, err := NewFakeClient(objects...)
+	if err != nil {
+		panic(err)
+	}
+	return fake
+}
+
+// AddObjects stores the given objects without recording an action.
+// The objects' kinds must be known to the client, see NewFakeClient.
+func (fake *FakeClient) AddObjects(objects ...NamedObject) error {
+	for _, obj := range objects {
+		resource, namespace, err := fake.resolveObjectScope(obj)
+		if err != nil {
+			return err
+		}
+
+		rawObject := &unstructured.Unstructured{Object: runtime.DeepCopyJSON(obj)}
+		if namespace != "" {
+			rawObject.SetNamespace(namespace)
+		}
+		if err := fake.dynamicClient.Tracker().Create(resource, rawObject, namespace); err != nil {
+			return errors.Wrapf(err, "failed to add %s", objectIdentifier(obj.GetName(), namespace))
+		}
+
+		// Built-in kinds are added to the typed clients, too
+		typedObject, err := scheme.Scheme.New(rawObject.GroupVersionKind())
+		if err != nil {
+			continue
+		}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(rawObject.Object, typedObject); err != nil {
+			return errors.Wrapf(err, "failed to convert %s", objectIdentifier(obj.GetName(), namespace))
+		}
+		if err := fake.clientset.Tracker().Create(resource, typedObject, namespace); err != nil {
+			return errors.Wrapf(err, "failed to add %s", objectIdentifier(obj.GetName(), namespace))
+		}
+	}
+
+	return nil
+}
+
+// InjectError lets all requests with the given verb on the given resource
+// fail with err. Verbs are named like in client-go, e.g. "get", "list",
+// "create", "update", "patch", "delete" or "watch". Subresources can be
+// addressed as e.g. "pods/eviction". Use "*" to match any verb or resource.
+// Passing a nil error removes a previously injected error.
+func (fake *FakeClient) InjectError(verb, resource string, err error) {
+	fake.lock.Lock()
+	defer fake.lock.Unlock()
+
+	key := fakeErrorKey{verb: verb, resource: resource}
+	if err == nil {
+		delete(fake.errors, key)
+		return
+	}
+	fake.errors[key] = err
+}
+
+// PrependReactor adds a reactor to both the dynamic and the typed fake
+// clients. Reactors can be used to implement custom responses, see
+// k8s.io/client-go/testing. Requests are recorded before reactors are called.
+func (fake *FakeClient) PrependReactor(verb, resource string, reaction k8stesting.ReactionFunc) {
+	// Keep the recording reactor first
+	insert := func(chain []k8stesting.Reactor) []k8stesting.Reactor {
+		reactor := &k8stesting.SimpleReactor{Verb: verb, Resource: resource, Reaction: reaction}
+		return append([]k8stesting.Reactor{chain[0], reactor}, chain[1:]...)
+	}
+
+	fake.dynamicClient.Lock()
+	fake.dynamicClient.ReactionChain = insert(fake.dynamicClient.ReactionChain)
+	fake.dynamicClient.Unlock()
+
+	fake.clientset.Lock()
+	fake.clientset.ReactionChain = insert(fake.clientset.ReactionChain)
+	fake.clientset.Unlock()
+}
+
+// Actions returns all requests sent by the client so far, in the order they
+// were sent.
+func (fake *FakeClient) Actions() []k8stesting.Action {
+	fake.lock.Lock()
+	defer fake.lock.Unlock()
+
+	actions := make([]k8stesting.Action, len(fake.actions))
+	copy(actions, fake.actions)
+	return actions
+}
+
+// ClearActions removes all recorded requests.
+func (fake *FakeClient) ClearActions() {
+	fake.lock.Lock()
+	defer fake.lock.Unlock()
+
+	fake.actions = nil
+}
+
+// react records the given action and returns an injected error, if any.
+func (fake *FakeClient) react(action k8stesting.Action) (bool, runtime.Object, error) {
+	if err := fake.record(action); err != nil {
+		return true, nil, err
+	}
+	return false, nil, nil
+}
+
+// reactApply emulates server-side apply on the dynamic client. Missing
+// objects are created, existing objects are merged with the applied object.
+// Dry-run requests return the result without storing it.
+func (fake *FakeClient) reactApply(action k8stesting.Action) (bool, runtime.Object, error) {
+	patchAction, ok := action.(k8stesting.PatchActionImpl)
+	if !ok || patchAction.GetPatchType() != types.ApplyPatchType {
+		return false, nil, nil
+	}
+
+	resource, namespace, name := action.GetResource(), action.GetNamespace(), patchAction.GetName()
+	applied := &unstructured.Unstructured{}
+	if err := json.Unmarshal(patchAction.GetPatch(), &applied.Object); err != nil {
+		return true, nil, apierrors.NewBadRequest(fmt.Sprintf("invalid apply patch for %s: %s", objectIdentifier(name, namespace), err))
+	}
+	applied.SetName(name)
+	if namespace != "" {
+		applied.SetNamespace(namespace)
+	}
+
+	tracker := fake.dynamicClient.Tracker()
+	dryRun := len(patchAction.PatchOptions.DryRun) > 0
+
+	existing, err := tracker.Get(resource, namespace, name)
+	switch {
+	case apierrors.IsNotFound(err):
+		if dryRun {
+			return true, applied, nil
+		}
+		err = tracker.Create(resource, applied, namespace)
+
+	case err != nil:
+		return true, nil, err
+
+	default:
+		merged, ok := existing.(*unstructured.Unstructured)
+		if !ok {
+			return true, nil, errors.Errorf("unexpected object type %T stored for %s", existing, objectIdentifier(name, namespace))
+		}
+		mergeAppliedFields(merged.Object, applied.Object)
+
+		if dryRun {
+			return true, merged, nil
+		}
+		err = tracker.Update(resource, merged, namespace)
+	}
+
+	if err != nil {
+		return true, nil, err
+	}
+	stored, err := tracker.Get(resource, namespace, name)
+	return true, stored, err
+}
+
+// mergeAppliedFields recursively merges the fields of an applied object into
+// a given object. Lists and values are replaced, null values remove a field.
+func mergeAppliedFields(target, applied map[string]interface{}) {
+	for key, value := range applied {
+		if value == nil {
+			delete(target, key)
+			continue
+		}
+
+		appliedSection, isMap := value.(map[string]interface{})
+		targetSection, targetIsMap := target[key].(map[string]interface{})
+		if isMap && targetIsMap {
+			mergeAppliedFields(targetSection, appliedSection)
+			continue
+		}
+		target[key] = value
+	}
+}
+
+// reactWatch records the given watch action and returns an injected error, if
+// any.
+func (fake *FakeClient) reactWatch(action k8stesting.Action) (bool, watch.Interface, error) {
+	if err := fake.record(action); err != nil {
+		return true, nil, err
+	}
+	return false, nil, nil
+}
+
+// record adds an action to the list of recorded actions and returns the
+// error injected for it.
+func (fake *FakeClient) record(action k8stesting.Action) error {
+	fake.lock.Lock()
+	defer fake.lock.Unlock()
+
+	fake.actions = append(fake.actions, action.DeepCopy())
+
+	resources := []string{action.GetResource().Resource, "*"}
+	if subresource := action.GetSubresource(); subresource != "" {
+		resources = []string{fmt.Sprintf("%s/%s", action.GetResource().Resource, subresource), "*"}
+	}
+
+	for _, verb := range []string{action.GetVerb(), "*"} {
+		for _, resource := range resources {
+			if err, ok := fake.errors[fakeErrorKey{verb: verb, resource: resource}]; ok {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// newFakeResource creates an entry for fakeResources.
+func newFakeResource(group, version, kind, resource string, namespaced bool, shortNames ...string) fakeResource {
+	return fakeResource{
+		ResourceInfo: ResourceInfo{
+			Resource:   schema.GroupVersionResource{Group: group, Version: version, Resource: resource},
+			Kind:       schema.GroupVersionKind{Group: group, Version: version, Kind: kind},
+			Namespaced: namespaced,
+		},
+		shortNames: shortNames,
+	}
+}
+
+// guessFakeResource derives a resource from an object's apiVersion and kind.
+// The object is treated as namespaced if it has a namespace set.
+func guessFakeResource(obj NamedObject) fakeResource {
+	kind := schema.FromAPIVersionAndKind(obj.GetVersion(), obj.GetKind())
+	resource, _ := meta.UnsafeGuessKindToResource(kind)
+
+	return fakeResource{
+		ResourceInfo: ResourceInfo{
+			Resource:   resource,
+			Kind:       kind,
+			Namespaced: obj.GetNamespace() != "",
+		},
+	}
+}
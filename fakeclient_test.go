@@ -0,0 +1,179 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestFakeClientSeeding(t *testing.T) {
+	configMap := newTestObject("v1", "ConfigMap", "config")
+	namespace := newTestObject("v1", "Namespace", "test")
+	widget := newTestObject("example.com/v1", "Widget", "widget")
+	assert.NoError(t, widget.SetNamespace("test"))
+
+	client := MustNewFakeClient(configMap, namespace, widget)
+	ctx := context.Background()
+
+	// Namespaced objects without namespace are stored in the default namespace
+	obj, err := client.GetNamespacedObject(ResourceConfigMap, "config", "default", ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "config", obj.GetName())
+
+	obj, err = client.GetNamedObject(ResourceNamespace, "test", ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "test", obj.GetName())
+
+	info, err := client.GVRForObject(widget)
+	assert.NoError(t, err)
+	assert.Equal(t, schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}, info.Resource)
+	assert.True(t, info.Namespaced)
+
+	widgets, err := client.ListAllObjectsInNamespace(info.Resource, "test", "", "", ctx)
+	assert.NoError(t, err)
+	assert.Len(t, widgets, 1)
+
+	// Built-in kinds are known without seeding
+	info, err = client.ResolveKind("apps/v1", "Deployment")
+	assert.NoError(t, err)
+	assert.Equal(t, ResourceDeployment, info.Resource)
+
+	// Resources can be resolved like on a real cluster
+	for _, name := range []string{"deploy", "deployment", "deployments.apps"} {
+		info, err = client.ResolveResource(name)
+		assert.NoError(t, err, name)
+		assert.Equal(t, ResourceDeployment, info.Resource, name)
+	}
+	assert.NoError(t, client.RefreshDiscovery(ctx))
+
+	// Seeding does not record actions
+	assert.Len(t, client.Actions(), 3)
+	assert.NoError(t, client.AddObjects(newTestObject("v1", "ConfigMap", "other")))
+	assert.Len(t, client.Actions(), 3)
+
+	// Objects of built-in kinds can be used with typed clients
+	pod := newTestObject("v1", "Pod", "pod")
+	assert.NoError(t, client.AddObjects(pod))
+	assert.NoError(t, client.EvictPod(pod, metav1.DeleteOptions{}, ctx))
+}
+
+func TestNewFakeClientDuplicateObject(t *testing.T) {
+	// Objects cannot be seeded twice
+	configMap := newTestObject("v1", "ConfigMap", "config")
+	_, err := NewFakeClient(configMap, configMap)
+	assert.Error(t, err)
+
+	assert.Panics(t, func() { MustNewFakeClient(configMap, configMap) })
+}
+
+func TestFakeClientApply(t *testing.T) {
+	seeded := newTestObject("v1", "ConfigMap", "seeded")
+	assert.NoError(t, seeded.Set(Path{"data", "kept"}, "value"))
+	assert.NoError(t, seeded.Set(Path{"data", "changed"}, "old"))
+
+	client := MustNewFakeClient(seeded)
+	ctx := context.Background()
+
+	// Missing objects are created
+	created := newTestObject("v1", "ConfigMap", "created")
+	assert.NoError(t, created.Set(Path{"data", "key"}, "value"))
+	obj, err := client.ApplyObjectAndGet(created, metav1.ApplyOptions{FieldManager: "test"}, ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "created", obj.GetName())
+	assert.Equal(t, "default", obj.GetNamespace())
+
+	value, err := obj.GetString(Path{"data", "key"})
+	assert.NoError(t, err)
+	assert.Equal(t, "value", value)
+
+	// Existing objects are merged
+	applied := newTestObject("v1", "ConfigMap", "seeded")
+	assert.NoError(t, applied.SetNamespace("default"))
+	assert.NoError(t, applied.Set(Path{"data", "changed"}, "new"))
+	assert.NoError(t, client.Apply(ResourceConfigMap, applied, metav1.ApplyOptions{FieldManager: "test"}, ctx))
+
+	obj, err = client.GetNamespacedObject(ResourceConfigMap, "seeded", "default", ctx)
+	assert.NoError(t, err)
+	value, err = obj.GetString(Path{"data", "changed"})
+	assert.NoError(t, err)
+	assert.Equal(t, "new", value)
+	value, err = obj.GetString(Path{"data", "kept"})
+	assert.NoError(t, err)
+	assert.Equal(t, "value", value)
+
+	// Dry-runs do not store objects
+	dryRun := newTestObject("v1", "ConfigMap", "dry-run")
+	_, err = client.ApplyObjectAndGet(dryRun, metav1.ApplyOptions{FieldManager: "test", DryRun: []string{metav1.DryRunAll}}, ctx)
+	assert.NoError(t, err)
+	_, err = client.GetNamespacedObject(ResourceConfigMap, "dry-run", "default", ctx)
+	assert.Error(t, err)
+
+	namespace := newTestObject("v1", "Namespace", "test")
+	results, err := client.ApplyAll([]NamedObject{created, namespace}, metav1.ApplyOptions{FieldManager: "test"}, ctx)
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+
+	_, err = client.GetNamedObject(ResourceNamespace, "test", ctx)
+	assert.NoError(t, err)
+}
+
+func TestFakeClientActions(t *testing.T) {
+	client := MustNewFakeClient(newTestObject("v1", "ConfigMap", "config"))
+	ctx := context.Background()
+
+	_, err := client.Mutate(ResourceConfigMap, "config", "default", func(obj NamedObject) error {
+		return obj.SetLabel("app", "test")
+	}, ctx)
+	assert.NoError(t, err)
+	assert.NoError(t, client.DeleteNamespaced(ResourceConfigMap, "config", "default", ctx))
+
+	verbs := []string{}
+	for _, action := range client.Actions() {
+		assert.Equal(t, "configmaps", action.GetResource().Resource)
+		verbs = append(verbs, action.GetVerb())
+	}
+	assert.Equal(t, []string{"get", "update", "delete"}, verbs)
+
+	client.ClearActions()
+	assert.Empty(t, client.Actions())
+}
+
+func TestFakeClientInjectError(t *testing.T) {
+	client := MustNewFakeClient(newTestObject("v1", "ConfigMap", "config"))
+	ctx := context.Background()
+	injected := errors.New("injected")
+
+	client.InjectError("get", "configmaps", injected)
+	_, err := client.GetNamespacedObject(ResourceConfigMap, "config", "default", ctx)
+	assert.ErrorIs(t, err, injected)
+
+	// Other verbs are not affected
+	_, err = client.ListAllObjectsInNamespace(ResourceConfigMap, "default", "", "", ctx)
+	assert.NoError(t, err)
+
+	client.InjectError("get", "configmaps", nil)
+	_, err = client.GetNamespacedObject(ResourceConfigMap, "config", "default", ctx)
+	assert.NoError(t, err)
+
+	client.InjectError("*", "*", injected)
+	_, err = client.Watch(ResourceConfigMap, "default", "", "", ctx)
+	assert.ErrorIs(t, err, injected)
+	client.InjectError("*", "*", nil)
+
+	client.InjectError("create", "pods/eviction", injected)
+	assert.ErrorIs(t, client.EvictPod(newTestObject("v1", "Pod", "pod"), metav1.DeleteOptions{}, ctx), injected)
+
+	// Custom reactors are called after recording
+	client.PrependReactor("delete", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, injected
+	})
+	client.ClearActions()
+	assert.ErrorIs(t, client.DeleteNamespaced(ResourceConfigMap, "config", "default", ctx), injected)
+	assert.Len(t, client.Actions(), 1)
+}
@@ -72,4 +72,19 @@ var (
 		Version:  "v1",
 		Resource: "statefulsets",
 	}
+
+	// ResourceEvent is the most commonly used GVR for Events
+	ResourceEvent = schema.GroupVersionResource{
+		Group:    "events.k8s.io",
+		Version:  "v1",
+		Resource: "events",
+	}
+
+	// ResourceCustomResourceDefinition is the most commonly used GVR for
+	// CustomResourceDefinitions
+	ResourceCustomResourceDefinition = schema.GroupVersionResource{
+		Group:    "apiextensions.k8s.io",
+		Version:  "v1",
+		Resource: "customresourcedefinitions",
+	}
 )
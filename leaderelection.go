@@ -0,0 +1,118 @@
+package kubernetes
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+const (
+	// DefaultLeaseDuration is the time other candidates wait before taking
+	// over a lease that has not been renewed.
+	DefaultLeaseDuration = 15 * time.Second
+
+	// DefaultRenewDeadline is the time the leader retries renewing its lease
+	// before giving up leadership.
+	DefaultRenewDeadline = 10 * time.Second
+
+	// DefaultRetryPeriod is the time candidates wait between attempts to
+	// acquire or renew a lease.
+	DefaultRetryPeriod = 2 * time.Second
+)
+
+// RunWithLeaderElection competes for leadership using a coordination.k8s.io/v1
+// Lease with the given name and namespace. The lease is created if it does
+// not exist. Once this candidate becomes leader, onStartedLeading is called
+// with a context that is cancelled when leadership ends. onStoppedLeading is
+// called after that, but only if leadership had been acquired. Both callbacks
+// are optional.
+// If identity is empty, the hostname is used. Each candidate must use a unique
+// identity, e.g. the pod name.
+// RunWithLeaderElection blocks until ctx is done or leadership has been lost,
+// and waits for onStartedLeading to return. The lease is released when ctx is
+// done, so another candidate can take over immediately. If leadership is lost
+// because the lease could not be renewed, ErrLeadershipLost is returned.
+// Leases are renewed using DefaultLeaseDuration, DefaultRenewDeadline and
+// DefaultRetryPeriod.
+func (k8s *Client) RunWithLeaderElection(leaseName, namespace, identity string, onStartedLeading func(context.Context), onStoppedLeading func(), ctx context.Context) error {
+	if identity == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return errors.Wrap(err, "failed to determine leader election identity")
+		}
+		identity = hostname
+	}
+
+	// OnStartedLeading is called asynchronously and may still be running, or
+	// not be started yet, when the elector returns.
+	var (
+		lock     sync.Mutex
+		leading  bool
+		finished bool
+		running  sync.WaitGroup
+	)
+
+	elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock: &resourcelock.LeaseLock{
+			LeaseMeta: metav1.ObjectMeta{
+				Name:      leaseName,
+				Namespace: namespace,
+			},
+			Client: k8s.apiClient.coordinationv1,
+			LockConfig: resourcelock.ResourceLockConfig{
+				Identity: identity,
+			},
+		},
+		LeaseDuration:   DefaultLeaseDuration,
+		RenewDeadline:   DefaultRenewDeadline,
+		RetryPeriod:     DefaultRetryPeriod,
+		ReleaseOnCancel: true,
+		Name:            leaseName,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(leaderCtx context.Context) {
+				lock.Lock()
+				if finished {
+					lock.Unlock()
+					return
+				}
+				leading = true
+				running.Add(1)
+				lock.Unlock()
+
+				defer running.Done()
+				if onStartedLeading != nil {
+					onStartedLeading(leaderCtx)
+				}
+			},
+			OnStoppedLeading: func() {},
+		},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to set up leader election for lease %s", objectIdentifier(leaseName, namespace))
+	}
+
+	elector.Run(ctx)
+
+	lock.Lock()
+	finished = true
+	lock.Unlock()
+
+	if !leading {
+		return nil
+	}
+	running.Wait()
+
+	if onStoppedLeading != nil {
+		onStoppedLeading()
+	}
+	if ctx.Err() == nil {
+		return ErrLeadershipLost(objectIdentifier(leaseName, namespace))
+	}
+	return nil
+}
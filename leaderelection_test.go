@@ -0,0 +1,72 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRunWithLeaderElection(t *testing.T) {
+	client := MustNewFakeClient()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	started := make(chan struct{})
+	stopped := false
+	result := make(chan error, 1)
+
+	go func() {
+		result <- client.RunWithLeaderElection("lock", "test", "first", func(leaderCtx context.Context) {
+			close(started)
+			<-leaderCtx.Done()
+		}, func() {
+			stopped = true
+		}, ctx)
+	}()
+
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("leadership not acquired")
+	}
+
+	lease, err := client.clientset.CoordinationV1().Leases("test").Get(ctx, "lock", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "first", *lease.Spec.HolderIdentity)
+
+	cancel()
+	assert.NoError(t, <-result)
+	assert.True(t, stopped)
+
+	// The lease is released on shutdown
+	lease, err = client.clientset.CoordinationV1().Leases("test").Get(context.Background(), "lock", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, *lease.Spec.HolderIdentity)
+}
+
+func TestRunWithLeaderElectionHeldLease(t *testing.T) {
+	lease := newTestObject("coordination.k8s.io/v1", "Lease", "lock")
+	assert.NoError(t, lease.SetNamespace("test"))
+	assert.NoError(t, lease.Set(Path{"spec"}, map[string]interface{}{
+		"holderIdentity":       "other",
+		"leaseDurationSeconds": int64(60),
+		"renewTime":            metav1.NowMicro().Format(metav1.RFC3339Micro),
+	}))
+
+	client := MustNewFakeClient(lease)
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	called := false
+	err := client.RunWithLeaderElection("lock", "test", "first", func(context.Context) {
+		called = true
+	}, func() {
+		called = true
+	}, ctx)
+
+	assert.NoError(t, err)
+	assert.False(t, called)
+}
@@ -0,0 +1,110 @@
+package kubernetes
+
+import (
+	"context"
+	"iter"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// DefaultPageSize is used by ListIter if no limit is set.
+const DefaultPageSize = int64(500)
+
+// ListOptions holds the parameters for paginated list calls.
+type ListOptions struct {
+	// Namespace to list objects from. If left empty, a global resource is
+	// expected or all namespaces are listed.
+	Namespace string
+
+	// LabelSelector is an optional label selector, e.g. "app=test".
+	LabelSelector string
+
+	// FieldSelector is an optional field selector, e.g. "metadata.name=test".
+	FieldSelector string
+
+	// Limit sets the maximum number of objects returned per page.
+	// If set to 0, all objects are returned at once.
+	Limit int64
+
+	// Continue holds the token returned by a previous call to ListPage.
+	// Leave empty to start with the first page.
+	Continue string
+}
+
+// ListPage returns a single page of objects for a given type and the token
+// required to fetch the next page. If the token is empty, there are no more
+// pages left.
+// Items that could not be converted into a NamedObject are still returned.
+// In that case an ErrPartialList error is returned, holding an ErrListItem
+// for each failed item.
+func (k8s *Client) ListPage(resource schema.GroupVersionResource, options ListOptions, ctx context.Context) ([]NamedObject, string, error) {
+	resourceHandle := k8s.getResourceHandle(resource, options.Namespace)
+
+	list, err := resourceHandle.List(ctx, metav1.ListOptions{
+		LabelSelector: options.LabelSelector,
+		FieldSelector: options.FieldSelector,
+		Limit:         options.Limit,
+		Continue:      options.Continue,
+	})
+	if err != nil {
+		return []NamedObject{}, "", err
+	}
+
+	var itemErrors ErrPartialList
+	resultList := make([]NamedObject, 0, len(list.Items))
+	for idx, rawObject := range list.Items {
+		obj, parseErr := NamedObjectFromUnstructured(rawObject)
+		if parseErr != nil {
+			itemErrors = append(itemErrors, ErrListItem{Index: idx, Err: parseErr})
+		}
+		resultList = append(resultList, obj)
+	}
+
+	if len(itemErrors) > 0 {
+		return resultList, list.GetContinue(), itemErrors
+	}
+	return resultList, list.GetContinue(), nil
+}
+
+// ListIter returns an iterator over all objects of a given type. Pages are
+// fetched lazily while iterating, using options.Limit as the page size or
+// DefaultPageSize if no limit is set.
+// Items that could not be converted are passed along with their conversion
+// error. If a page cannot be fetched, the error is passed with a nil object
+// and iteration stops.
+func (k8s *Client) ListIter(resource schema.GroupVersionResource, options ListOptions, ctx context.Context) iter.Seq2[NamedObject, error] {
+	if options.Limit <= 0 {
+		options.Limit = DefaultPageSize
+	}
+
+	return func(yield func(NamedObject, error) bool) {
+		for {
+			items, continueToken, err := k8s.ListPage(resource, options, ctx)
+
+			var itemErrors ErrPartialList
+			if err != nil && !errors.As(err, &itemErrors) {
+				yield(nil, err)
+				return
+			}
+
+			itemErrorIdx := 0
+			for idx, obj := range items {
+				var itemErr error
+				if itemErrorIdx < len(itemErrors) && itemErrors[itemErrorIdx].Index == idx {
+					itemErr = itemErrors[itemErrorIdx]
+					itemErrorIdx++
+				}
+				if !yield(obj, itemErr) {
+					return
+				}
+			}
+
+			if continueToken == "" {
+				return
+			}
+			options.Continue = continueToken
+		}
+	}
+}
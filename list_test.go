@@ -0,0 +1,121 @@
+package kubernetes
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// pagingDynamicClient serves numItems config maps in pages according to the
+// limit and continue options of a list call. The dynamic fake client does not
+// support paging.
+type pagingDynamicClient struct {
+	dynamic.Interface
+	numItems int
+}
+
+type pagingResourceClient struct {
+	dynamic.NamespaceableResourceInterface
+	numItems int
+}
+
+func (c pagingDynamicClient) Resource(resource schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	return pagingResourceClient{c.Interface.Resource(resource), c.numItems}
+}
+
+func (c pagingResourceClient) List(ctx context.Context, options metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	start, _ := strconv.Atoi(options.Continue)
+	end := c.numItems
+	if options.Limit > 0 && start+int(options.Limit) < c.numItems {
+		end = start + int(options.Limit)
+	}
+
+	list := &unstructured.UnstructuredList{Object: map[string]interface{}{}}
+	for i := start; i < end; i++ {
+		list.Items = append(list.Items, *newTestConfigMap(strconv.Itoa(i)))
+	}
+	if end < c.numItems {
+		list.SetContinue(strconv.Itoa(end))
+	}
+	return list, nil
+}
+
+func newPagingTestClient(numItems int) Client {
+	return Client{client: pagingDynamicClient{newTestDynamicClient(), numItems}}
+}
+
+func TestListPage(t *testing.T) {
+	client := newPagingTestClient(5)
+
+	items, continueToken, err := client.ListPage(ResourceConfigMap, ListOptions{Limit: 2}, context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, items, 2)
+	assert.Equal(t, "2", continueToken)
+
+	items, continueToken, err = client.ListPage(ResourceConfigMap, ListOptions{Limit: 2, Continue: "4"}, context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+	assert.Equal(t, "4", items[0].GetName())
+	assert.Empty(t, continueToken)
+}
+
+func TestListIter(t *testing.T) {
+	client := newPagingTestClient(5)
+
+	names := []string{}
+	for obj, err := range client.ListIter(ResourceConfigMap, ListOptions{Limit: 2}, context.Background()) {
+		assert.NoError(t, err)
+		names = append(names, obj.GetName())
+	}
+	assert.Equal(t, []string{"0", "1", "2", "3", "4"}, names)
+
+	// Stop early
+	names = names[:0]
+	for obj := range client.ListIter(ResourceConfigMap, ListOptions{Limit: 2}, context.Background()) {
+		names = append(names, obj.GetName())
+		if len(names) == 3 {
+			break
+		}
+	}
+	assert.Equal(t, []string{"0", "1", "2"}, names)
+}
+
+func TestListPartialErrors(t *testing.T) {
+	unnamed := newTestConfigMap("")
+	unstructured.RemoveNestedField(unnamed.Object, "metadata", "name")
+
+	fakeClient := newTestDynamicClient()
+	fakeClient.PrependReactor("list", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &unstructured.UnstructuredList{
+			Object: map[string]interface{}{},
+			Items:  []unstructured.Unstructured{*newTestConfigMap("a"), *unnamed, *newTestConfigMap("b")},
+		}, nil
+	})
+	client := Client{client: fakeClient}
+
+	items, err := client.ListAllObjects(ResourceConfigMap, "", "", context.Background())
+	assert.Len(t, items, 3)
+
+	var partialErr ErrPartialList
+	assert.ErrorAs(t, err, &partialErr)
+	assert.Len(t, partialErr, 1)
+	assert.Equal(t, 1, partialErr[0].Index)
+	assert.ErrorIs(t, err, ErrMissingName{})
+
+	itemErrors := []error{}
+	for _, err := range client.ListIter(ResourceConfigMap, ListOptions{}, context.Background()) {
+		itemErrors = append(itemErrors, err)
+	}
+	assert.Len(t, itemErrors, 3)
+	assert.NoError(t, itemErrors[0])
+	assert.ErrorIs(t, itemErrors[1], ErrMissingName{})
+	assert.NoError(t, itemErrors[2])
+}
@@ -0,0 +1,267 @@
+package kubernetes
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	yamlv3 "go.yaml.in/yaml/v3"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// Manifest holds an object read by one of the manifest loaders, together with
+// the location it was read from.
+type Manifest struct {
+	// Object holds the object as found in the document.
+	Object NamedObject
+
+	// Path holds the file the object was read from. It is empty for objects
+	// read by LoadManifests.
+	Path string
+
+	// Index holds the index of the document the object was read from.
+	// Objects expanded from a "List" share the index of the list document.
+	Index int
+}
+
+// manifestExtensions lists the file extensions read by LoadManifestsFromDir.
+var manifestExtensions = map[string]bool{
+	".yaml": true,
+	".yml":  true,
+	".json": true,
+}
+
+// LoadManifests reads all kubernetes objects from a stream of YAML or JSON
+// documents. YAML documents are separated by "---". Objects of kind "List"
+// are expanded into their items.
+// Each object is returned with the index of the document it was read from.
+func LoadManifests(reader io.Reader) ([]Manifest, error) {
+	return loadManifests(reader, "")
+}
+
+// LoadManifestsFromFile reads all kubernetes objects from a given YAML or
+// JSON file. See LoadManifests.
+// Each object is returned with the path and document index it was read from.
+func LoadManifestsFromFile(path string) ([]Manifest, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return []Manifest{}, errors.Wrapf(err, "failed to open manifest %s", path)
+	}
+	defer file.Close()
+
+	return loadManifests(file, path)
+}
+
+// LoadManifestsFromDir reads all kubernetes objects from all files with a
+// .yaml, .yml or .json extension in a given directory. Files are read in
+// lexical order. If recursive is set to true, sub-directories are read, too.
+// See LoadManifestsFromFile.
+func LoadManifestsFromDir(path string, recursive bool) ([]Manifest, error) {
+	manifests := []Manifest{}
+
+	err := filepath.WalkDir(path, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			if !recursive && filePath != path {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !manifestExtensions[strings.ToLower(filepath.Ext(filePath))] {
+			return nil
+		}
+
+		fileManifests, err := LoadManifestsFromFile(filePath)
+		if err != nil {
+			return err
+		}
+		manifests = append(manifests, fileManifests...)
+		return nil
+	})
+
+	return manifests, err
+}
+
+// ManifestObjects returns the objects of a list of manifests, e.g. to pass
+// them to ApplyAll.
+func ManifestObjects(manifests []Manifest) []NamedObject {
+	objects := make([]NamedObject, 0, len(manifests))
+	for _, manifest := range manifests {
+		objects = append(objects, manifest.Object)
+	}
+	return objects
+}
+
+// loadManifests reads all objects from a given stream and returns them with
+// the given path and their document index.
+func loadManifests(reader io.Reader, path string) ([]Manifest, error) {
+	manifests := []Manifest{}
+	decoder := yaml.NewYAMLOrJSONDecoder(reader, 4096)
+
+	for index := 0; ; index++ {
+		document := map[string]interface{}{}
+		if err := decoder.Decode(&document); err != nil {
+			if err == io.EOF {
+				return manifests, nil
+			}
+			return manifests, ErrInvalidManifest{Path: path, Index: index, Err: err}
+		}
+
+		// Skip empty documents
+		if len(document) == 0 {
+			continue
+		}
+
+		documentObjects, err := parseManifestDocument(document)
+		if err != nil {
+			return manifests, ErrInvalidManifest{Path: path, Index: index, Err: err}
+		}
+
+		for _, obj := range documentObjects {
+			manifests = append(manifests, Manifest{Object: obj, Path: path, Index: index})
+		}
+	}
+}
+
+// parseManifestDocument converts a single document into a list of objects.
+// Documents of kind "List" are expanded into their items.
+func parseManifestDocument(document map[string]interface{}) ([]NamedObject, error) {
+	if kind, _ := document["kind"].(string); kind != "List" {
+		obj, err := NamedObjectFromUnstructured(unstructured.Unstructured{Object: document})
+		if err != nil {
+			return nil, err
+		}
+		return []NamedObject{obj}, nil
+	}
+
+	items, ok := document["items"].([]interface{})
+	if !ok {
+		if document["items"] == nil {
+			return []NamedObject{}, nil
+		}
+		return nil, ErrIncorrectType(fmt.Sprintf("items is %T", document["items"]))
+	}
+
+	objects := make([]NamedObject, 0, len(items))
+	for i, item := range items {
+		itemMap, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, ErrIncorrectType(fmt.Sprintf("items[%d] is %T", i, item))
+		}
+
+		// Lists may be nested
+		itemObjects, err := parseManifestDocument(itemMap)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse items[%d]", i)
+		}
+		objects = append(objects, itemObjects...)
+	}
+
+	return objects, nil
+}
+
+// manifestKeyOrder defines the order of the top-level keys written by
+// WriteManifests. All other keys are ordered alphabetically.
+var manifestKeyOrder = map[string]int{
+	"apiVersion": 0,
+	"kind":       1,
+	"metadata":   2,
+	"spec":       3,
+	"status":     4,
+}
+
+// WriteManifests writes a list of objects as a multi-document YAML stream.
+// Keys are ordered as described in NamedObject.ToYAML, so that the output is
+// stable and can be diffed. Use RemoveManagedFields to strip server-side
+// fields before writing.
+func WriteManifests(writer io.Writer, objects []NamedObject) error {
+	encoder := yamlv3.NewEncoder(writer)
+	encoder.SetIndent(2)
+
+	for i, obj := range objects {
+		node, err := toYAMLNode(map[string]interface{}(obj), true)
+		if err != nil {
+			return errors.Wrapf(err, "failed to convert object %d (%s)", i, obj.GetName())
+		}
+		if err := encoder.Encode(node); err != nil {
+			return errors.Wrapf(err, "failed to write object %d (%s)", i, obj.GetName())
+		}
+	}
+
+	return encoder.Close()
+}
+
+// toYAMLNode converts a value into a YAML node with ordered keys.
+// If topLevel is true, the keys listed in manifestKeyOrder come first.
+func toYAMLNode(value interface{}, topLevel bool) (*yamlv3.Node, error) {
+	switch v := value.(type) {
+	case NamedObject:
+		return toYAMLNode(map[string]interface{}(v), topLevel)
+
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			if topLevel {
+				iOrder, iKnown := manifestKeyOrder[keys[i]]
+				jOrder, jKnown := manifestKeyOrder[keys[j]]
+				switch {
+				case iKnown && jKnown:
+					return iOrder < jOrder
+				case iKnown != jKnown:
+					return iKnown
+				}
+			}
+			return keys[i] < keys[j]
+		})
+
+		node := &yamlv3.Node{Kind: yamlv3.MappingNode, Tag: "!!map"}
+		for _, k := range keys {
+			valueNode, err := toYAMLNode(v[k], false)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to convert %s", k)
+			}
+			node.Content = append(node.Content,
+				&yamlv3.Node{Kind: yamlv3.ScalarNode, Tag: "!!str", Value: k},
+				valueNode)
+		}
+		return node, nil
+
+	case []interface{}:
+		node := &yamlv3.Node{Kind: yamlv3.SequenceNode, Tag: "!!seq"}
+		for i, element := range v {
+			elementNode, err := toYAMLNode(element, false)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to convert element %d", i)
+			}
+			node.Content = append(node.Content, elementNode)
+		}
+		return node, nil
+
+	case float64:
+		// JSON numbers are parsed as float64. Integers are written as such to
+		// avoid exponent notation for large values.
+		if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+			value = int64(v)
+		}
+	}
+
+	node := &yamlv3.Node{}
+	if err := node.Encode(value); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
@@ -0,0 +1,174 @@
+package kubernetes
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	multiDocumentYAML = `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: test
+---
+# empty document
+---
+apiVersion: v1
+kind: List
+items:
+  - apiVersion: v1
+    kind: ConfigMap
+    metadata:
+      name: first
+      namespace: test
+  - apiVersion: v1
+    kind: ConfigMap
+    metadata:
+      name: second
+      namespace: test
+    data:
+      replicas: "3"
+`
+
+	multiDocumentJSON = `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "a"}}
+{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "b"}}`
+
+	unnamedYAML = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: named
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  namespace: test
+`
+)
+
+func TestLoadManifests(t *testing.T) {
+	manifests, err := LoadManifests(strings.NewReader(multiDocumentYAML))
+	assert.NoError(t, err)
+	assert.Len(t, manifests, 3)
+
+	objects := ManifestObjects(manifests)
+	assert.True(t, objects[0].IsOfKind("Namespace", "v1"))
+	assert.Equal(t, "first", objects[1].GetName())
+	assert.Equal(t, "second", objects[2].GetName())
+
+	value, err := objects[2].GetString(Path{"data", "replicas"})
+	assert.NoError(t, err)
+	assert.Equal(t, "3", value)
+
+	assert.Equal(t, "", manifests[0].Path)
+	assert.Equal(t, 0, manifests[0].Index)
+	assert.Equal(t, 2, manifests[2].Index)
+
+	// The location is not stored in the object itself
+	assert.False(t, objects[0].HasAnnotations())
+
+	manifests, err = LoadManifests(strings.NewReader(multiDocumentJSON))
+	assert.NoError(t, err)
+	assert.Len(t, manifests, 2)
+	assert.Equal(t, "b", manifests[1].Object.GetName())
+
+	manifests, err = LoadManifests(strings.NewReader(unnamedYAML))
+	assert.Len(t, manifests, 1)
+	var manifestErr ErrInvalidManifest
+	assert.ErrorAs(t, err, &manifestErr)
+	assert.Equal(t, 1, manifestErr.Index)
+	assert.ErrorIs(t, err, ErrMissingName{})
+}
+
+func TestLoadManifestsFromDir(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "a.yaml"), []byte(multiDocumentYAML), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# not a manifest"), 0o600))
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "sub"), 0o700))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "b.json"), []byte(multiDocumentJSON), 0o600))
+
+	manifests, err := LoadManifestsFromDir(dir, false)
+	assert.NoError(t, err)
+	assert.Len(t, manifests, 3)
+	assert.Equal(t, filepath.Join(dir, "a.yaml"), manifests[1].Path)
+	assert.Equal(t, 2, manifests[1].Index)
+
+	manifests, err = LoadManifestsFromDir(dir, true)
+	assert.NoError(t, err)
+	assert.Len(t, manifests, 5)
+	assert.Equal(t, filepath.Join(dir, "sub", "b.json"), manifests[4].Path)
+	assert.Equal(t, 1, manifests[4].Index)
+}
+
+func TestToYAML(t *testing.T) {
+	obj := NamedObject{
+		"zzz":    "last",
+		"status": map[string]interface{}{"phase": "Active"},
+		"data": map[string]interface{}{
+			"b":      "2",
+			"a":      "1",
+			"number": "10",
+		},
+		"spec": map[string]interface{}{
+			"replicas": float64(3),
+			"memory":   float64(1073741824),
+			"ratio":    0.5,
+			"list":     []interface{}{"x", map[string]interface{}{"b": true, "a": nil}},
+		},
+		"metadata":   map[string]interface{}{"namespace": "test", "name": "test"},
+		"kind":       "ConfigMap",
+		"apiVersion": "v1",
+	}
+
+	expected := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+  namespace: test
+spec:
+  list:
+    - x
+    - a: null
+      b: true
+  memory: 1073741824
+  ratio: 0.5
+  replicas: 3
+status:
+  phase: Active
+data:
+  a: "1"
+  b: "2"
+  number: "10"
+zzz: last
+`
+
+	yamlStr, err := obj.ToYAML()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, yamlStr)
+
+	// Output must be stable
+	for i := 0; i < 10; i++ {
+		again, err := obj.ToYAML()
+		assert.NoError(t, err)
+		assert.Equal(t, yamlStr, again)
+	}
+}
+
+func TestWriteManifests(t *testing.T) {
+	manifests, err := LoadManifests(strings.NewReader(multiDocumentYAML))
+	assert.NoError(t, err)
+	objects := ManifestObjects(manifests)
+
+	var buffer strings.Builder
+	assert.NoError(t, WriteManifests(&buffer, objects))
+	assert.Equal(t, 2, strings.Count(buffer.String(), "---\n"))
+
+	reloaded, err := LoadManifests(strings.NewReader(buffer.String()))
+	assert.NoError(t, err)
+	assert.Equal(t, objects, ManifestObjects(reloaded))
+}
@@ -398,6 +398,16 @@ func (obj NamedObject) ToJSON() (string, error) {
 	return string(data), err
 }
 
+// ToYAML generates a YAML string out of this object.
+// Keys are ordered deterministically, starting with apiVersion, kind,
+// metadata, spec and status, followed by all other keys in alphabetical
+// order. Nested keys are ordered alphabetically.
+func (obj NamedObject) ToYAML() (string, error) {
+	var buffer strings.Builder
+	err := WriteManifests(&buffer, []NamedObject{obj})
+	return buffer.String(), err
+}
+
 // Hash calculates an ordered hash of the object.
 func (obj NamedObject) Hash() (uint64, error) {
 	hasher := xxhash.New()
@@ -3,6 +3,32 @@
 
 package kubernetes
 
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// PatchJSON denotes a JSON patch (RFC 6902), built from a list of
+	// PatchOperations.
+	PatchJSON = types.JSONPatchType
+
+	// PatchMerge denotes a JSON merge patch (RFC 7386), built from an object
+	// fragment. Lists are replaced as a whole.
+	PatchMerge = types.MergePatchType
+
+	// PatchStrategic denotes a strategic merge patch, built from an object
+	// fragment. Lists are merged based on their patch strategy. This patch
+	// type is not supported for custom resources.
+	PatchStrategic = types.StrategicMergePatchType
+
+	// PatchApply denotes a server-side apply patch, built from an object
+	// fragment. This patch type requires PatchOptions.FieldManager to be set.
+	PatchApply = types.ApplyPatchType
+)
+
 // PatchOperation is an operation of a JSON patch https://tools.ietf.org/html/rfc6902.
 // This is required to report changes back through an admissionreview response.
 type PatchOperation struct {
@@ -55,3 +81,28 @@ func NewPatchOperationMove(from, path string) PatchOperation {
 		From: from,
 	}
 }
+
+// marshalPatch converts a patch into the format expected for a given patch
+// type. PatchJSON accepts a []PatchOperation, all other types accept an object
+// fragment. A []byte is returned as-is.
+func marshalPatch(patchType types.PatchType, patch interface{}) ([]byte, error) {
+	switch p := patch.(type) {
+	case []byte:
+		return p, nil
+
+	case []PatchOperation:
+		if patchType != PatchJSON {
+			return nil, ErrInvalidPatch(fmt.Sprintf("%s does not accept %T", patchType, patch))
+		}
+
+	case NamedObject, map[string]interface{}:
+		if patchType == PatchJSON {
+			return nil, ErrInvalidPatch(fmt.Sprintf("%s does not accept %T", patchType, patch))
+		}
+
+	default:
+		return nil, ErrInvalidPatch(fmt.Sprintf("unsupported patch of type %T", patch))
+	}
+
+	return json.Marshal(patch)
+}
@@ -0,0 +1,77 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// newTestPatchResult returns an object for a patch action, like the API server
+// would return it.
+func newTestPatchResult(action k8stesting.Action) *unstructured.Unstructured {
+	patch := action.(k8stesting.PatchAction)
+	obj := &unstructured.Unstructured{Object: NewNamedObject(patch.GetName())}
+	obj.SetNamespace(patch.GetNamespace())
+	return obj
+}
+
+func TestMarshalPatch(t *testing.T) {
+	data, err := marshalPatch(PatchJSON, []PatchOperation{NewPatchOperationRemove("/spec/replicas")})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[{"op":"remove","path":"/spec/replicas"}]`, string(data))
+
+	data, err = marshalPatch(PatchMerge, NamedObject{"spec": map[string]interface{}{"replicas": 3}})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"spec":{"replicas":3}}`, string(data))
+
+	data, err = marshalPatch(PatchStrategic, []byte(`{"spec":{}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"spec":{}}`, string(data))
+
+	_, err = marshalPatch(PatchMerge, []PatchOperation{})
+	assert.ErrorAs(t, err, new(ErrInvalidPatch))
+
+	_, err = marshalPatch(PatchJSON, NamedObject{})
+	assert.ErrorAs(t, err, new(ErrInvalidPatch))
+
+	_, err = marshalPatch(PatchApply, "invalid")
+	assert.ErrorAs(t, err, new(ErrInvalidPatch))
+}
+
+func TestPatchWithType(t *testing.T) {
+	fakeClient := newTestDynamicClient()
+	fakeClient.PrependReactor("patch", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, newTestPatchResult(action), nil
+	})
+	client := Client{client: fakeClient}
+
+	obj := newTestObject("apps/v1", "Deployment", "test")
+	assert.NoError(t, obj.SetNamespace("default"))
+
+	options := metav1.PatchOptions{
+		DryRun:       []string{metav1.DryRunAll},
+		FieldManager: "test",
+	}
+	ctx := context.Background()
+
+	assert.NoError(t, client.Patch(ResourceDeployment, obj, []PatchOperation{NewPatchOperationRemove("/spec/replicas")}, options, ctx))
+	assert.NoError(t, client.PatchWithType(ResourceDeployment, obj, PatchStrategic, NamedObject{"spec": map[string]interface{}{}}, options, ctx))
+
+	actions := fakeClient.Actions()
+	assert.Len(t, actions, 2)
+
+	jsonPatch := actions[0].(k8stesting.PatchActionImpl)
+	assert.Equal(t, PatchJSON, jsonPatch.GetPatchType())
+	assert.Equal(t, options, jsonPatch.PatchOptions)
+	assert.Equal(t, "default", jsonPatch.GetNamespace())
+
+	strategicPatch := actions[1].(k8stesting.PatchActionImpl)
+	assert.Equal(t, PatchStrategic, strategicPatch.GetPatchType())
+	assert.Equal(t, options, strategicPatch.PatchOptions)
+	assert.JSONEq(t, `{"spec":{}}`, string(strategicPatch.GetPatch()))
+}
@@ -0,0 +1,240 @@
+package kubernetes
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes/scheme"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// ExecOptions defines the command and streams used by ExecInPod.
+type ExecOptions struct {
+	// Command holds the command and its arguments.
+	Command []string
+
+	// Container holds the name of the container to run the command in.
+	// May be left empty for pods with a single container.
+	Container string
+
+	// Stdin, Stdout and Stderr are connected to the command if set.
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+
+	// TTY allocates a terminal for the command. Stderr is merged into Stdout
+	// in this case.
+	TTY bool
+}
+
+// PortForwardOptions defines additional settings for PortForward.
+type PortForwardOptions struct {
+	// Addresses holds the local addresses to listen on.
+	// Defaults to "localhost".
+	Addresses []string
+
+	// OnReady is called once all ports are being forwarded. It receives the
+	// forwarded ports, which is required to learn the local port if it was
+	// set to 0.
+	OnReady func(ports []portforward.ForwardedPort)
+
+	// Out and ErrOut receive status messages and errors of forwarded
+	// connections. Messages are discarded if not set.
+	Out    io.Writer
+	ErrOut io.Writer
+}
+
+// PodReference returns a NamedObject identifying a pod by name and namespace.
+// It can be passed to functions expecting a pod, like StreamPodLogs, if the
+// pod object itself is not at hand.
+func PodReference(name, namespace string) NamedObject {
+	return NamedObject{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": namespace,
+		},
+	}
+}
+
+// StreamPodLogs returns the logs of a given pod as a stream. Use the options
+// to select a container, follow the logs, or limit them by time (SinceSeconds,
+// SinceTime) or number of lines (TailLines). The stream must be closed by the
+// caller. If options.Follow is set, the stream is open until ctx is done or
+// the container stops.
+// Pods without a namespace are expected in the default namespace. Use
+// PodReference to address a pod by name and namespace.
+func (k8s *Client) StreamPodLogs(pod NamedObject, options corev1.PodLogOptions, ctx context.Context) (io.ReadCloser, error) {
+	name, namespace := podNameAndNamespace(pod)
+
+	stream, err := k8s.apiClient.corev1.Pods(namespace).GetLogs(name, &options).Stream(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to stream logs of pod %s", objectIdentifier(name, namespace))
+	}
+
+	return stream, nil
+}
+
+// ExecInPod runs a command in a container of a given pod and connects the
+// given streams. It blocks until the command has finished or ctx is done.
+// WebSockets are used to connect to the API server, falling back to SPDY for
+// older servers. If the command exits with a non-zero code, an error
+// implementing k8s.io/client-go/util/exec.ExitError is returned.
+// Pods without a namespace are expected in the default namespace. Use
+// PodReference to address a pod by name and namespace.
+func (k8s *Client) ExecInPod(pod NamedObject, options ExecOptions, ctx context.Context) error {
+	if k8s.config == nil {
+		return ErrMissingRESTConfig{}
+	}
+
+	name, namespace := podNameAndNamespace(pod)
+	identifier := objectIdentifier(name, namespace)
+
+	request := k8s.podSubresourceRequest(name, namespace, "exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: options.Container,
+			Command:   options.Command,
+			Stdin:     options.Stdin != nil,
+			Stdout:    options.Stdout != nil,
+			Stderr:    options.Stderr != nil && !options.TTY,
+			TTY:       options.TTY,
+		}, scheme.ParameterCodec)
+
+	websocketExecutor, err := remotecommand.NewWebSocketExecutor(k8s.config, "GET", request.URL().String())
+	if err != nil {
+		return errors.Wrapf(err, "failed to create websocket executor for pod %s", identifier)
+	}
+	spdyExecutor, err := remotecommand.NewSPDYExecutor(k8s.config, "POST", request.URL())
+	if err != nil {
+		return errors.Wrapf(err, "failed to create SPDY executor for pod %s", identifier)
+	}
+	executor, err := remotecommand.NewFallbackExecutor(websocketExecutor, spdyExecutor, shouldFallbackToSPDY)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create executor for pod %s", identifier)
+	}
+
+	streams := remotecommand.StreamOptions{
+		Stdin:  options.Stdin,
+		Stdout: options.Stdout,
+		Stderr: options.Stderr,
+		Tty:    options.TTY,
+	}
+	if options.TTY {
+		streams.Stderr = nil
+	}
+
+	if err := executor.StreamWithContext(ctx, streams); err != nil {
+		return errors.Wrapf(err, "failed to execute command in pod %s", identifier)
+	}
+	return nil
+}
+
+// PortForward forwards local ports to a given pod. Ports are given in the
+// format used by kubectl: "8080" forwards local port 8080 to pod port 8080,
+// "8080:80" forwards local port 8080 to pod port 80 and ":80" forwards a
+// random local port to pod port 80. PortForward blocks until ctx is done or
+// the connection to the pod is lost.
+// Pods without a namespace are expected in the default namespace. Use
+// PodReference to address a pod by name and namespace.
+func (k8s *Client) PortForward(pod NamedObject, ports []string, options PortForwardOptions, ctx context.Context) error {
+	if k8s.config == nil {
+		return ErrMissingRESTConfig{}
+	}
+
+	name, namespace := podNameAndNamespace(pod)
+	identifier := objectIdentifier(name, namespace)
+	url := k8s.podSubresourceRequest(name, namespace, "portforward").URL()
+
+	transport, upgrader, err := spdy.RoundTripperFor(k8s.config)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create SPDY transport for pod %s", identifier)
+	}
+	spdyDialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", url)
+
+	websocketDialer, err := portforward.NewSPDYOverWebsocketDialer(url, k8s.config)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create websocket dialer for pod %s", identifier)
+	}
+	dialer := portforward.NewFallbackDialer(websocketDialer, spdyDialer, shouldFallbackToSPDY)
+
+	addresses := options.Addresses
+	if len(addresses) == 0 {
+		addresses = []string{"localhost"}
+	}
+	out, errOut := options.Out, options.ErrOut
+	if out == nil {
+		out = io.Discard
+	}
+	if errOut == nil {
+		errOut = io.Discard
+	}
+
+	stop := make(chan struct{})
+	ready := make(chan struct{})
+	forwarder, err := portforward.NewOnAddresses(dialer, addresses, ports, stop, ready, out, errOut)
+	if err != nil {
+		return errors.Wrapf(err, "failed to set up port forwarding for pod %s", identifier)
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			close(stop)
+		case <-done:
+		}
+	}()
+
+	if options.OnReady != nil {
+		go func() {
+			select {
+			case <-ready:
+				if forwardedPorts, err := forwarder.GetPorts(); err == nil {
+					options.OnReady(forwardedPorts)
+				}
+			case <-done:
+			}
+		}()
+	}
+
+	if err := forwarder.ForwardPorts(); err != nil {
+		return errors.Wrapf(err, "failed to forward ports to pod %s", identifier)
+	}
+	return nil
+}
+
+// podSubresourceRequest creates a request for a subresource of a given pod.
+func (k8s *Client) podSubresourceRequest(name, namespace, subresource string) *restclient.Request {
+	return k8s.apiClient.corev1.RESTClient().Post().
+		Resource("pods").
+		Name(name).
+		Namespace(namespace).
+		SubResource(subresource)
+}
+
+// podNameAndNamespace returns the name and namespace of a given pod.
+// The namespace defaults to metav1.NamespaceDefault.
+func podNameAndNamespace(pod NamedObject) (string, string) {
+	namespace := pod.GetNamespace()
+	if namespace == "" {
+		namespace = metav1.NamespaceDefault
+	}
+	return pod.GetName(), namespace
+}
+
+// shouldFallbackToSPDY returns true if a websocket connection failed because
+// the API server does not support websockets for streaming.
+func shouldFallbackToSPDY(err error) bool {
+	return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+}
@@ -0,0 +1,103 @@
+package kubernetes
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	restclient "k8s.io/client-go/rest"
+)
+
+// newTestRequestRecorder returns a client talking to a server that rejects
+// all requests and records their URLs.
+func newTestRequestRecorder(t *testing.T) (*Client, func() []*url.URL) {
+	var (
+		lock     sync.Mutex
+		requests []*url.URL
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		requests = append(requests, r.URL)
+		lock.Unlock()
+		http.Error(w, "not supported", http.StatusBadRequest)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClientFromRESTConfig(&restclient.Config{Host: server.URL})
+	assert.NoError(t, err)
+
+	return client, func() []*url.URL {
+		lock.Lock()
+		defer lock.Unlock()
+		return append([]*url.URL{}, requests...)
+	}
+}
+
+func TestPodReference(t *testing.T) {
+	pod := PodReference("pod", "test")
+	assert.Equal(t, "Pod", pod.GetKind())
+	assert.Equal(t, "pod", pod.GetName())
+	assert.Equal(t, "test", pod.GetNamespace())
+
+	name, namespace := podNameAndNamespace(PodReference("pod", ""))
+	assert.Equal(t, "pod", name)
+	assert.Equal(t, "default", namespace)
+}
+
+func TestStreamPodLogs(t *testing.T) {
+	client := MustNewFakeClient(newTestObject("v1", "Pod", "pod"))
+
+	stream, err := client.StreamPodLogs(PodReference("pod", "default"), corev1.PodLogOptions{Container: "main"}, context.Background())
+	assert.NoError(t, err)
+	defer stream.Close()
+
+	logs, err := io.ReadAll(stream)
+	assert.NoError(t, err)
+	assert.Equal(t, "fake logs", string(logs))
+}
+
+func TestExecInPod(t *testing.T) {
+	ctx := context.Background()
+	options := ExecOptions{
+		Command:   []string{"ls", "-l"},
+		Container: "main",
+		Stdout:    io.Discard,
+	}
+
+	// Fake clients cannot open streams
+	err := MustNewFakeClient().ExecInPod(PodReference("pod", "test"), options, ctx)
+	assert.ErrorIs(t, err, ErrMissingRESTConfig{})
+
+	client, requests := newTestRequestRecorder(t)
+	assert.Error(t, client.ExecInPod(PodReference("pod", "test"), options, ctx))
+
+	if assert.NotEmpty(t, requests()) {
+		request := requests()[0]
+		assert.Equal(t, "/api/v1/namespaces/test/pods/pod/exec", request.Path)
+		assert.Equal(t, []string{"ls", "-l"}, request.Query()["command"])
+		assert.Equal(t, "main", request.Query().Get("container"))
+		assert.Equal(t, "true", request.Query().Get("stdout"))
+		assert.Empty(t, request.Query().Get("stdin"))
+	}
+}
+
+func TestPortForward(t *testing.T) {
+	ctx := context.Background()
+
+	err := MustNewFakeClient().PortForward(PodReference("pod", "test"), []string{"8080"}, PortForwardOptions{}, ctx)
+	assert.ErrorIs(t, err, ErrMissingRESTConfig{})
+
+	client, requests := newTestRequestRecorder(t)
+	assert.Error(t, client.PortForward(PodReference("pod", ""), []string{":8080"}, PortForwardOptions{}, ctx))
+
+	if assert.NotEmpty(t, requests()) {
+		assert.Equal(t, "/api/v1/namespaces/default/pods/pod/portforward", requests()[0].Path)
+	}
+}
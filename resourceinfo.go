@@ -0,0 +1,104 @@
+package kubernetes
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ResourceInfo describes a resource type as it is known to the API server.
+type ResourceInfo struct {
+	// Resource holds the GVR to be used with Client functions.
+	Resource schema.GroupVersionResource
+
+	// Kind holds the GVK of objects of this resource.
+	Kind schema.GroupVersionKind
+
+	// Namespaced is true if objects of this resource are namespace scoped.
+	Namespaced bool
+}
+
+// ResolveResource resolves a resource name as accepted by kubectl into a
+// ResourceInfo. The name can be given as plural ("deployments"), singular
+// ("deployment"), short name ("deploy") and may be qualified with a group
+// and version, e.g. "deployments.apps" or "deployments.v1.apps".
+func (k8s *Client) ResolveResource(name string) (ResourceInfo, error) {
+	var (
+		resource schema.GroupVersionResource
+		err      error
+	)
+
+	fullySpecified, groupResource := schema.ParseResourceArg(strings.ToLower(name))
+	if fullySpecified != nil {
+		resource, err = k8s.groupResourceMapper.ResourceFor(*fullySpecified)
+	}
+	if fullySpecified == nil || err != nil {
+		resource, err = k8s.groupResourceMapper.ResourceFor(groupResource.WithVersion(""))
+		if err != nil {
+			return ResourceInfo{}, errors.Wrapf(err, "failed to resolve resource %s", name)
+		}
+	}
+
+	kind, err := k8s.groupResourceMapper.KindFor(resource)
+	if err != nil {
+		return ResourceInfo{}, errors.Wrapf(err, "failed to resolve kind for resource %s", resource.String())
+	}
+
+	return k8s.resolveMapping(kind.GroupKind(), kind.Version)
+}
+
+// ResolveKind resolves an apiVersion and kind, as found in a manifest, into a
+// ResourceInfo. If apiVersion is left empty, the preferred version of the
+// kind is used.
+func (k8s *Client) ResolveKind(apiVersion, kind string) (ResourceInfo, error) {
+	groupVersion, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return ResourceInfo{}, errors.Wrapf(err, "failed to parse apiVersion %s", apiVersion)
+	}
+
+	if groupVersion.Version == "" {
+		return k8s.resolveMapping(groupVersion.WithKind(kind).GroupKind())
+	}
+	return k8s.resolveMapping(groupVersion.WithKind(kind).GroupKind(), groupVersion.Version)
+}
+
+// GVRForObject resolves the resource of a given object, based on its
+// apiVersion and kind fields.
+func (k8s *Client) GVRForObject(obj NamedObject) (ResourceInfo, error) {
+	return k8s.ResolveKind(obj.GetVersion(), obj.GetKind())
+}
+
+// resolveMapping converts a group kind into a ResourceInfo using the RESTMapper.
+func (k8s *Client) resolveMapping(groupKind schema.GroupKind, versions ...string) (ResourceInfo, error) {
+	mapping, err := k8s.groupResourceMapper.RESTMapping(groupKind, versions...)
+	if err != nil {
+		return ResourceInfo{}, errors.Wrapf(err, "failed to resolve mapping for %s", groupKind.String())
+	}
+
+	return ResourceInfo{
+		Resource:   mapping.Resource,
+		Kind:       mapping.GroupVersionKind,
+		Namespaced: mapping.Scope.Name() == meta.RESTScopeNameNamespace,
+	}, nil
+}
+
+// resolveObjectScope returns the resource and namespace to use for a given
+// object. The namespace is empty for cluster-wide resources and defaults to
+// metav1.NamespaceDefault for namespaced resources without a namespace.
+func (k8s *Client) resolveObjectScope(obj NamedObject) (schema.GroupVersionResource, string, error) {
+	info, err := k8s.GVRForObject(obj)
+	if err != nil {
+		return schema.GroupVersionResource{}, "", err
+	}
+
+	if !info.Namespaced {
+		return info.Resource, "", nil
+	}
+	if namespace := obj.GetNamespace(); namespace != "" {
+		return info.Resource, namespace, nil
+	}
+	return info.Resource, metav1.NamespaceDefault, nil
+}
@@ -0,0 +1,124 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery/cached/memory"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var testAPIResources = []*metav1.APIResourceList{
+	{
+		GroupVersion: "v1",
+		APIResources: []metav1.APIResource{
+			{Name: "configmaps", SingularName: "configmap", Kind: "ConfigMap", Namespaced: true, ShortNames: []string{"cm"}},
+			{Name: "namespaces", SingularName: "namespace", Kind: "Namespace", Namespaced: false, ShortNames: []string{"ns"}},
+		},
+	},
+	{
+		GroupVersion: "apps/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "deployments", SingularName: "deployment", Kind: "Deployment", Namespaced: true, ShortNames: []string{"deploy"}},
+		},
+	},
+}
+
+func newTestMapperClient(t *testing.T) Client {
+	discoveryClient := memory.NewMemCacheClient(&fakediscovery.FakeDiscovery{
+		Fake: &k8stesting.Fake{Resources: testAPIResources},
+	})
+
+	return Client{
+		discoveryClient:     discoveryClient,
+		groupResourceMapper: newRESTMapper(discoveryClient),
+	}
+}
+
+func TestResolveResource(t *testing.T) {
+	client := newTestMapperClient(t)
+
+	for _, name := range []string{"deploy", "deployment", "Deployments", "deployments.apps", "deployments.v1.apps"} {
+		info, err := client.ResolveResource(name)
+		assert.NoError(t, err, name)
+		assert.Equal(t, ResourceDeployment, info.Resource, name)
+		assert.Equal(t, "Deployment", info.Kind.Kind, name)
+		assert.True(t, info.Namespaced, name)
+	}
+
+	info, err := client.ResolveResource("ns")
+	assert.NoError(t, err)
+	assert.Equal(t, ResourceNamespace, info.Resource)
+	assert.False(t, info.Namespaced)
+
+	_, err = client.ResolveResource("unknown")
+	assert.Error(t, err)
+}
+
+func TestResolveKind(t *testing.T) {
+	client := newTestMapperClient(t)
+
+	info, err := client.ResolveKind("apps/v1", "Deployment")
+	assert.NoError(t, err)
+	assert.Equal(t, ResourceDeployment, info.Resource)
+	assert.True(t, info.Namespaced)
+
+	obj := NewNamedObject("test")
+	obj["apiVersion"] = "v1"
+	obj["kind"] = "ConfigMap"
+
+	info, err = client.GVRForObject(obj)
+	assert.NoError(t, err)
+	assert.Equal(t, ResourceConfigMap, info.Resource)
+	assert.Equal(t, schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, info.Kind)
+
+	_, err = client.ResolveKind("v1", "Unknown")
+	assert.Error(t, err)
+}
+
+func TestObjectScope(t *testing.T) {
+	fakeClient := newTestDynamicClient()
+	fakeClient.PrependReactor("*", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetVerb() == "patch" {
+			return true, newTestPatchResult(action), nil
+		}
+		return true, nil, nil
+	})
+
+	client := newTestMapperClient(t)
+	client.client = fakeClient
+
+	configMap := NewNamedObject("test")
+	configMap["apiVersion"] = "v1"
+	configMap["kind"] = "ConfigMap"
+
+	namespace := NewNamedObject("test")
+	namespace["apiVersion"] = "v1"
+	namespace["kind"] = "Namespace"
+	assert.NoError(t, namespace.SetNamespace("ignored"))
+
+	ctx := context.Background()
+	assert.NoError(t, client.DeleteObject(configMap, ctx))
+	assert.NoError(t, client.DeleteObject(namespace, ctx))
+	assert.NoError(t, client.PatchObject(configMap, []PatchOperation{NewPatchOperationAdd("/data", map[string]interface{}{})}, metav1.PatchOptions{}, ctx))
+	assert.NoError(t, client.ApplyObject(namespace, metav1.ApplyOptions{FieldManager: "test"}, ctx))
+
+	actions := fakeClient.Actions()
+	assert.Len(t, actions, 4)
+	assert.Equal(t, "default", actions[0].GetNamespace())
+	assert.Equal(t, ResourceConfigMap, actions[0].GetResource())
+	assert.Equal(t, "", actions[1].GetNamespace())
+	assert.Equal(t, ResourceNamespace, actions[1].GetResource())
+	assert.Equal(t, "default", actions[2].GetNamespace())
+	assert.Equal(t, "", actions[3].GetNamespace())
+
+	unknown := NewNamedObject("test")
+	unknown["apiVersion"] = "example.com/v1"
+	unknown["kind"] = "Unknown"
+	assert.Error(t, client.DeleteObject(unknown, ctx))
+}
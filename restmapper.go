@@ -0,0 +1,146 @@
+package kubernetes
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/restmapper"
+)
+
+// discoveryResetInterval is the minimum time between two automatic resets of
+// the discovery information. This prevents lookups of unknown resources from
+// triggering a full discovery on every call.
+var discoveryResetInterval = 5 * time.Second
+
+// refreshingRESTMapper wraps a RESTMapper and resets it once if a lookup
+// fails because a resource or kind is not known. This allows resources that
+// have been installed after client creation, e.g. CRDs, to be resolved.
+type refreshingRESTMapper struct {
+	meta.RESTMapper
+	lock      sync.Mutex
+	lastReset time.Time
+}
+
+// newRESTMapper creates a lazily initialized RESTMapper that supports short
+// names and is refreshed whenever an unknown resource or kind is requested.
+// The discovery client is expected to cache its results.
+func newRESTMapper(discoveryClient discovery.CachedDiscoveryInterface) meta.RESTMapper {
+	return &refreshingRESTMapper{
+		RESTMapper: restmapper.NewShortcutExpander(
+			restmapper.NewDeferredDiscoveryRESTMapper(discoveryClient),
+			discoveryClient,
+			nil),
+	}
+}
+
+// RefreshDiscovery drops all cached discovery information and loads it again.
+// Resources installed after the client has been created are picked up
+// automatically on lookup. This function can be used to force a reload, e.g.
+// after a CRD has been created.
+func (k8s *Client) RefreshDiscovery(ctx context.Context) error {
+	meta.MaybeResetRESTMapper(k8s.groupResourceMapper)
+
+	// The discovery client does not accept a context, so we wait for the
+	// result in the background.
+	done := make(chan error, 1)
+	go func() {
+		_, err := restmapper.GetAPIGroupResources(k8s.discoveryClient)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		return errors.Wrapf(err, "failed to refresh discovery information")
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Reset drops all cached discovery information.
+// Implements meta.ResettableRESTMapper.
+func (m *refreshingRESTMapper) Reset() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	meta.MaybeResetRESTMapper(m.RESTMapper)
+	m.lastReset = time.Now()
+}
+
+// resetOnNoMatch resets the discovery information if err denotes an unknown
+// resource or kind and the last reset is older than discoveryResetInterval.
+// Returns true if a reset was done.
+func (m *refreshingRESTMapper) resetOnNoMatch(err error) bool {
+	if !meta.IsNoMatchError(err) {
+		return false
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if time.Since(m.lastReset) < discoveryResetInterval {
+		return false
+	}
+
+	meta.MaybeResetRESTMapper(m.RESTMapper)
+	m.lastReset = time.Now()
+	return true
+}
+
+// KindFor implements meta.RESTMapper.
+func (m *refreshingRESTMapper) KindFor(resource schema.GroupVersionResource) (schema.GroupVersionKind, error) {
+	kind, err := m.RESTMapper.KindFor(resource)
+	if m.resetOnNoMatch(err) {
+		return m.RESTMapper.KindFor(resource)
+	}
+	return kind, err
+}
+
+// KindsFor implements meta.RESTMapper.
+func (m *refreshingRESTMapper) KindsFor(resource schema.GroupVersionResource) ([]schema.GroupVersionKind, error) {
+	kinds, err := m.RESTMapper.KindsFor(resource)
+	if m.resetOnNoMatch(err) {
+		return m.RESTMapper.KindsFor(resource)
+	}
+	return kinds, err
+}
+
+// ResourceFor implements meta.RESTMapper.
+func (m *refreshingRESTMapper) ResourceFor(input schema.GroupVersionResource) (schema.GroupVersionResource, error) {
+	resource, err := m.RESTMapper.ResourceFor(input)
+	if m.resetOnNoMatch(err) {
+		return m.RESTMapper.ResourceFor(input)
+	}
+	return resource, err
+}
+
+// ResourcesFor implements meta.RESTMapper.
+func (m *refreshingRESTMapper) ResourcesFor(input schema.GroupVersionResource) ([]schema.GroupVersionResource, error) {
+	resources, err := m.RESTMapper.ResourcesFor(input)
+	if m.resetOnNoMatch(err) {
+		return m.RESTMapper.ResourcesFor(input)
+	}
+	return resources, err
+}
+
+// RESTMapping implements meta.RESTMapper.
+func (m *refreshingRESTMapper) RESTMapping(groupKind schema.GroupKind, versions ...string) (*meta.RESTMapping, error) {
+	mapping, err := m.RESTMapper.RESTMapping(groupKind, versions...)
+	if m.resetOnNoMatch(err) {
+		return m.RESTMapper.RESTMapping(groupKind, versions...)
+	}
+	return mapping, err
+}
+
+// RESTMappings implements meta.RESTMapper.
+func (m *refreshingRESTMapper) RESTMappings(groupKind schema.GroupKind, versions ...string) ([]*meta.RESTMapping, error) {
+	mappings, err := m.RESTMapper.RESTMappings(groupKind, versions...)
+	if m.resetOnNoMatch(err) {
+		return m.RESTMapper.RESTMappings(groupKind, versions...)
+	}
+	return mappings, err
+}
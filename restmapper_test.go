@@ -0,0 +1,52 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery/cached/memory"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestRESTMapperRefresh(t *testing.T) {
+	fake := &k8stesting.Fake{Resources: append([]*metav1.APIResourceList{}, testAPIResources...)}
+	discoveryClient := memory.NewMemCacheClient(&fakediscovery.FakeDiscovery{Fake: fake})
+	client := Client{
+		discoveryClient:     discoveryClient,
+		groupResourceMapper: newRESTMapper(discoveryClient),
+	}
+
+	_, err := client.ResolveKind("apps/v1", "Deployment")
+	assert.NoError(t, err)
+
+	// Simulate a CRD being installed after the first lookup
+	fake.Resources = append(fake.Resources, &metav1.APIResourceList{
+		GroupVersion: "example.com/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "widgets", SingularName: "widget", Kind: "Widget", Namespaced: true},
+		},
+	})
+
+	info, err := client.ResolveKind("example.com/v1", "Widget")
+	assert.NoError(t, err)
+	assert.Equal(t, "widgets", info.Resource.Resource)
+
+	// Automatic resets are rate limited, so a forced refresh is required
+	fake.Resources = append(fake.Resources, &metav1.APIResourceList{
+		GroupVersion: "example.com/v1beta1",
+		APIResources: []metav1.APIResource{
+			{Name: "gadgets", SingularName: "gadget", Kind: "Gadget", Namespaced: false},
+		},
+	})
+
+	_, err = client.ResolveResource("gadgets")
+	assert.Error(t, err)
+
+	assert.NoError(t, client.RefreshDiscovery(context.Background()))
+	info, err = client.ResolveResource("gadgets")
+	assert.NoError(t, err)
+	assert.False(t, info.Namespaced)
+}
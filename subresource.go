@@ -0,0 +1,127 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// SubresourceStatus is the name of the status subresource.
+	SubresourceStatus = "status"
+
+	// SubresourceScale is the name of the scale subresource, which is
+	// available on Deployments, StatefulSets, ReplicaSets and other scalable
+	// resources.
+	SubresourceScale = "scale"
+)
+
+// GetSubresource returns a subresource of a specific kubernetes object, e.g.
+// SubresourceStatus or SubresourceScale.
+// If an empty namespace is given, the object will be treated as a cluster-wide resource.
+func (k8s *Client) GetSubresource(resource schema.GroupVersionResource, name, namespace, subresource string, ctx context.Context) (NamedObject, error) {
+	rawObject, err := k8s.getResourceHandle(resource, namespace).Get(ctx, name, metav1.GetOptions{}, subresource)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get %s of %s", subresource, objectIdentifier(name, namespace))
+	}
+
+	return NamedObjectFromUnstructured(*rawObject)
+}
+
+// UpdateStatus replaces the status of a given kubernetes object. All other
+// fields of the object are ignored by the API server.
+// The object's resourceVersion is used for optimistic concurrency, so the
+// update fails with a conflict if the object has been changed in the meantime.
+// The updated object is returned.
+func (k8s *Client) UpdateStatus(resource schema.GroupVersionResource, object NamedObject, options metav1.UpdateOptions, ctx context.Context) (NamedObject, error) {
+	namespace := object.GetNamespace()
+	unstructuredObject := &unstructured.Unstructured{
+		Object: object,
+	}
+
+	rawObject, err := k8s.getResourceHandle(resource, namespace).UpdateStatus(ctx, unstructuredObject, options)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to update status of %s", objectIdentifier(object.GetName(), namespace))
+	}
+
+	return NamedObjectFromUnstructured(*rawObject)
+}
+
+// PatchStatus applies a patch of a given type on the status of a given
+// kubernetes object. See PatchWithType for supported patch formats.
+// The updated object is returned.
+func (k8s *Client) PatchStatus(resource schema.GroupVersionResource, object NamedObject, patchType types.PatchType, patch interface{}, options metav1.PatchOptions, ctx context.Context) (NamedObject, error) {
+	return k8s.patch(resource, object.GetNamespace(), object, patchType, patch, options, SubresourceStatus, ctx)
+}
+
+// GetScale returns the scale subresource of a specific kubernetes object.
+// The returned object is an autoscaling/v1 Scale, holding the desired number
+// of replicas in spec.replicas and the current number of replicas and the
+// pod selector in status.
+func (k8s *Client) GetScale(resource schema.GroupVersionResource, name, namespace string, ctx context.Context) (NamedObject, error) {
+	return k8s.GetSubresource(resource, name, namespace, SubresourceScale, ctx)
+}
+
+// SetScale sets the desired number of replicas of a specific kubernetes
+// object through its scale subresource.
+// The updated autoscaling/v1 Scale object is returned.
+func (k8s *Client) SetScale(resource schema.GroupVersionResource, name, namespace string, replicas int32, ctx context.Context) (NamedObject, error) {
+	object := NamedObject{
+		"metadata": map[string]interface{}{
+			"name": name,
+		},
+	}
+	patch := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"replicas": replicas,
+		},
+	}
+
+	return k8s.patch(resource, namespace, object, PatchMerge, patch, metav1.PatchOptions{}, SubresourceScale, ctx)
+}
+
+// EvictPod requests the eviction of a given pod through the policy/v1
+// eviction API. In contrast to deleting a pod, evictions respect
+// PodDisruptionBudgets. If the eviction would violate a budget,
+// ErrEvictionBlocked is returned and the eviction can be retried later.
+// Pods without a namespace are expected in the default namespace.
+func (k8s *Client) EvictPod(pod NamedObject, options metav1.DeleteOptions, ctx context.Context) error {
+	namespace := pod.GetNamespace()
+	if namespace == "" {
+		namespace = metav1.NamespaceDefault
+	}
+
+	eviction := &policyv1.Eviction{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pod.GetName(),
+			Namespace: namespace,
+		},
+		DeleteOptions: &options,
+	}
+
+	identifier := objectIdentifier(pod.GetName(), namespace)
+	if err := k8s.apiClient.corev1.Pods(namespace).EvictV1(ctx, eviction); err != nil {
+		if apierrors.IsTooManyRequests(err) {
+			return ErrEvictionBlocked{Pod: identifier, Err: err}
+		}
+		return errors.Wrapf(err, "failed to evict pod %s", identifier)
+	}
+
+	return nil
+}
+
+// objectIdentifier returns a name that identifies an object in error messages.
+// If an empty namespace is given, the name is returned as-is.
+func objectIdentifier(name, namespace string) string {
+	if namespace == "" {
+		return name
+	}
+	return fmt.Sprintf("%s/%s", namespace, name)
+}
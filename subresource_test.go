@@ -0,0 +1,92 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubernetesfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestStatusSubresource(t *testing.T) {
+	deployment := newTestObject("apps/v1", "Deployment", "test")
+	assert.NoError(t, deployment.SetNamespace("default"))
+	fakeClient := newTestDynamicClient(&unstructured.Unstructured{Object: deployment})
+	client := Client{client: fakeClient}
+	ctx := context.Background()
+
+	status, err := client.GetSubresource(ResourceDeployment, "test", "default", SubresourceStatus, ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "test", status.GetName())
+
+	assert.NoError(t, status.Set(Path{"status", "replicas"}, int64(3)))
+	updated, err := client.UpdateStatus(ResourceDeployment, status, metav1.UpdateOptions{}, ctx)
+	assert.NoError(t, err)
+	replicas, err := updated.Get(Path{"status", "replicas"})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, replicas)
+
+	patch := map[string]interface{}{
+		"status": map[string]interface{}{"readyReplicas": 2},
+	}
+	updated, err = client.PatchStatus(ResourceDeployment, deployment, PatchMerge, patch, metav1.PatchOptions{}, ctx)
+	assert.NoError(t, err)
+	readyReplicas, err := updated.Get(Path{"status", "readyReplicas"})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, readyReplicas)
+
+	actions := fakeClient.Actions()
+	assert.Len(t, actions, 3)
+	for _, action := range actions {
+		assert.Equal(t, SubresourceStatus, action.GetSubresource())
+	}
+}
+
+func TestSetScale(t *testing.T) {
+	deployment := newTestObject("apps/v1", "Deployment", "test")
+	assert.NoError(t, deployment.SetNamespace("default"))
+	fakeClient := newTestDynamicClient(&unstructured.Unstructured{Object: deployment})
+	client := Client{client: fakeClient}
+	ctx := context.Background()
+
+	_, err := client.SetScale(ResourceDeployment, "test", "default", 5, ctx)
+	assert.NoError(t, err)
+
+	actions := fakeClient.Actions()
+	assert.Len(t, actions, 1)
+	assert.Equal(t, SubresourceScale, actions[0].GetSubresource())
+	assert.JSONEq(t, `{"spec":{"replicas":5}}`, string(actions[0].(k8stesting.PatchAction).GetPatch()))
+}
+
+func TestEvictPod(t *testing.T) {
+	fakeClientset := kubernetesfake.NewClientset(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+	})
+	client := Client{apiClient: typedClients{corev1: fakeClientset.CoreV1()}}
+	pod := newTestObject("v1", "Pod", "test")
+	ctx := context.Background()
+
+	assert.NoError(t, client.EvictPod(pod, metav1.DeleteOptions{}, ctx))
+
+	actions := fakeClientset.Actions()
+	assert.Len(t, actions, 1)
+	assert.Equal(t, "eviction", actions[0].GetSubresource())
+	assert.Equal(t, "default", actions[0].GetNamespace())
+
+	// Evictions violating a disruption budget are rejected with 429
+	fakeClientset.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 10)
+	})
+
+	err := client.EvictPod(pod, metav1.DeleteOptions{}, ctx)
+	var blockedErr ErrEvictionBlocked
+	assert.ErrorAs(t, err, &blockedErr)
+	assert.Equal(t, "default/test", blockedErr.Pod)
+	assert.True(t, apierrors.IsTooManyRequests(err))
+}
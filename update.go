@@ -0,0 +1,90 @@
+package kubernetes
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+)
+
+// conflictBackoff defines the delay between attempts of Mutate to update an
+// object that has been changed concurrently.
+var conflictBackoff = wait.Backoff{
+	Steps:    5,
+	Duration: 10 * time.Millisecond,
+	Factor:   3.0,
+	Jitter:   0.1,
+}
+
+// Update replaces a given kubernetes object.
+// If metadata.resourceVersion is set, the update only succeeds if the object
+// has not been changed since it was read. Otherwise the API server returns a
+// 409 Conflict, which can be checked using apierrors.IsConflict. Objects
+// without a resourceVersion are replaced unconditionally.
+// If a namespace is set, the object is expected in that namespace.
+// The updated object is returned.
+func (k8s *Client) Update(resource schema.GroupVersionResource, object NamedObject, ctx context.Context) (NamedObject, error) {
+	namespace := object.GetNamespace()
+	unstructuredObject := &unstructured.Unstructured{
+		Object: object,
+	}
+
+	rawObject, err := k8s.getResourceHandle(resource, namespace).Update(ctx, unstructuredObject, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to update %s", objectIdentifier(object.GetName(), namespace))
+	}
+
+	return NamedObjectFromUnstructured(*rawObject)
+}
+
+// Mutate implements a read-modify-write cycle on a specific kubernetes
+// object. The object is fetched, passed to the mutate function and updated
+// using its resourceVersion. If the object has been changed in the meantime,
+// the cycle is repeated with backoff, so the mutate function may be called
+// more than once. It must not have side effects besides changing the object.
+// If the mutate function returns an error, Mutate stops and returns that
+// error. If ctx is done between attempts, the context error is returned.
+// If an empty namespace is given, the object will be treated as a cluster-wide resource.
+// The updated object is returned.
+func (k8s *Client) Mutate(resource schema.GroupVersionResource, name, namespace string, mutate func(NamedObject) error, ctx context.Context) (NamedObject, error) {
+	var updated NamedObject
+
+	// RetryOnConflict reports the last conflict instead of context errors
+	var ctxErr error
+
+	err := retry.RetryOnConflict(conflictBackoff, func() error {
+		if ctxErr = ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+
+		rawObject, err := k8s.getResourceHandle(resource, namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return errors.Wrapf(err, "failed to get %s", objectIdentifier(name, namespace))
+		}
+
+		object, err := NamedObjectFromUnstructured(*rawObject)
+		if err != nil {
+			return err
+		}
+
+		if err := mutate(object); err != nil {
+			return err
+		}
+
+		updated, err = k8s.Update(resource, object, ctx)
+		return err
+	})
+
+	if ctxErr != nil {
+		return nil, ctxErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
@@ -0,0 +1,83 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestUpdate(t *testing.T) {
+	fakeClient := newTestDynamicClient(newTestConfigMap("test"))
+	client := Client{client: fakeClient}
+	ctx := context.Background()
+
+	obj, err := client.GetNamespacedObject(ResourceConfigMap, "test", "default", ctx)
+	assert.NoError(t, err)
+	assert.NoError(t, obj.SetLabel("app", "test"))
+
+	updated, err := client.Update(ResourceConfigMap, obj, ctx)
+	assert.NoError(t, err)
+	label, err := updated.GetLabel("app")
+	assert.NoError(t, err)
+	assert.Equal(t, "test", label)
+}
+
+func TestMutate(t *testing.T) {
+	fakeClient := newTestDynamicClient(newTestConfigMap("test"))
+	client := Client{client: fakeClient}
+	ctx := context.Background()
+
+	// Fail the first two updates with a conflict
+	conflicts := 0
+	fakeClient.PrependReactor("update", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if conflicts < 2 {
+			conflicts++
+			return true, nil, apierrors.NewConflict(ResourceConfigMap.GroupResource(), "test", nil)
+		}
+		return false, nil, nil
+	})
+
+	calls := 0
+	updated, err := client.Mutate(ResourceConfigMap, "test", "default", func(obj NamedObject) error {
+		calls++
+		return obj.SetLabel("app", "test")
+	}, ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+
+	label, err := updated.GetLabel("app")
+	assert.NoError(t, err)
+	assert.Equal(t, "test", label)
+
+	// Errors of the mutate function are returned as-is
+	mutateErr := ErrNotFound("test")
+	_, err = client.Mutate(ResourceConfigMap, "test", "default", func(obj NamedObject) error {
+		return mutateErr
+	}, ctx)
+	assert.Equal(t, mutateErr, err)
+}
+
+func TestMutateCancelled(t *testing.T) {
+	fakeClient := newTestDynamicClient(newTestConfigMap("test"))
+	client := Client{client: fakeClient}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Cancel the caller during the first attempt, which then conflicts
+	fakeClient.PrependReactor("update", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		cancel()
+		return true, nil, apierrors.NewConflict(ResourceConfigMap.GroupResource(), "test", nil)
+	})
+
+	calls := 0
+	_, err := client.Mutate(ResourceConfigMap, "test", "default", func(obj NamedObject) error {
+		calls++
+		return nil
+	}, ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,237 @@
+package kubernetes
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// WaitPredicate checks if an object has reached the state waited for by
+// WaitFor. It returns an error if the object can never reach that state, e.g.
+// because a rollout has failed, which stops WaitFor immediately.
+type WaitPredicate func(NamedObject) (bool, error)
+
+// WaitFor waits until a specific kubernetes object matches a given predicate
+// and returns the matching object. The predicate is checked whenever the
+// object is created or changed, starting with its current state. If the
+// object does not exist yet, WaitFor waits for it to be created.
+// If ctx is done before the predicate matches, the context error is returned.
+// If the predicate returns an error, WaitFor stops and returns that error.
+// If an empty namespace is given, the object will be treated as a cluster-wide resource.
+// See ConditionTrue, RolloutComplete, JobSucceeded and PathEquals for common
+// predicates.
+func (k8s *Client) WaitFor(resource schema.GroupVersionResource, name, namespace string, predicate WaitPredicate, ctx context.Context) (NamedObject, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	fieldSelector := fields.OneTermEqualSelector("metadata.name", name).String()
+	events, err := k8s.Watch(resource, namespace, "", fieldSelector, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The object is checked once after the watch has been established, so
+	// that no change can be missed in between.
+	if obj, err := k8s.GetNamespacedObject(resource, name, namespace, ctx); err == nil {
+		if matched, err := predicate(obj); err != nil || matched {
+			return waitResult(obj, name, namespace, err)
+		}
+	}
+
+	for event := range events {
+		switch event.Type {
+		case watch.Error:
+			return nil, errors.Wrapf(event.Err, "failed to wait for %s", objectIdentifier(name, namespace))
+
+		case watch.Added, watch.Modified:
+			if event.Err != nil || event.Object.GetName() != name {
+				continue
+			}
+			if matched, err := predicate(event.Object); err != nil || matched {
+				return waitResult(event.Object, name, namespace, err)
+			}
+		}
+	}
+
+	return nil, errors.Wrapf(ctx.Err(), "failed to wait for %s", objectIdentifier(name, namespace))
+}
+
+// waitResult returns the result of WaitFor for an object that either matched
+// or caused the predicate to fail.
+func waitResult(obj NamedObject, name, namespace string, err error) (NamedObject, error) {
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to wait for %s", objectIdentifier(name, namespace))
+	}
+	return obj, nil
+}
+
+// ConditionTrue returns a predicate that matches objects having a status
+// condition of the given type, e.g. "Ready" or "Available", set to "True".
+func ConditionTrue(conditionType string) WaitPredicate {
+	return func(obj NamedObject) (bool, error) {
+		return hasCondition(obj, conditionType, "True"), nil
+	}
+}
+
+// PathEquals returns a predicate that matches objects having the given value
+// at the given path. Numbers are compared by value, regardless of their type.
+func PathEquals(path Path, value interface{}) WaitPredicate {
+	return func(obj NamedObject) (bool, error) {
+		actual, err := obj.Get(path)
+		if err != nil {
+			return false, nil
+		}
+
+		actualNumber, actualIsNumber := toFloat64(actual)
+		expectedNumber, expectedIsNumber := toFloat64(value)
+		if actualIsNumber && expectedIsNumber {
+			return actualNumber == expectedNumber, nil
+		}
+		return reflect.DeepEqual(actual, value), nil
+	}
+}
+
+// JobSucceeded is a predicate that matches Jobs that have completed
+// successfully.
+func JobSucceeded(obj NamedObject) (bool, error) {
+	return hasCondition(obj, "Complete", "True"), nil
+}
+
+// RolloutComplete is a predicate that matches Deployments, StatefulSets and
+// DaemonSets whose latest revision has been fully rolled out and is
+// available. It follows the rules of "kubectl rollout status".
+// If a Deployment has exceeded its progress deadline, ErrRolloutFailed is
+// returned. Objects of other kinds never match.
+func RolloutComplete(obj NamedObject) (bool, error) {
+	// The controller must have processed the latest spec
+	generation, _ := getInt64(obj, Path{"metadata", "generation"})
+	observedGeneration, ok := getInt64(obj, Path{"status", "observedGeneration"})
+	if !ok || observedGeneration < generation {
+		return false, nil
+	}
+
+	switch obj.GetKind() {
+	case "Deployment":
+		if err := checkDeploymentProgress(obj); err != nil {
+			return false, err
+		}
+		return isDeploymentRolledOut(obj), nil
+	case "StatefulSet":
+		return isStatefulSetRolledOut(obj), nil
+	case "DaemonSet":
+		return isDaemonSetRolledOut(obj), nil
+	default:
+		return false, nil
+	}
+}
+
+// checkDeploymentProgress returns ErrRolloutFailed if a Deployment has
+// exceeded its progress deadline.
+func checkDeploymentProgress(obj NamedObject) error {
+	conditions, _ := obj.GetList(Path{"status", "conditions"})
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok || condition["type"] != "Progressing" {
+			continue
+		}
+		if condition["reason"] == "ProgressDeadlineExceeded" {
+			message, _ := condition["message"].(string)
+			return ErrRolloutFailed{
+				Object:  objectIdentifier(obj.GetName(), obj.GetNamespace()),
+				Reason:  "ProgressDeadlineExceeded",
+				Message: message,
+			}
+		}
+	}
+	return nil
+}
+
+// isDeploymentRolledOut returns true if all replicas of a Deployment have
+// been updated and are available.
+func isDeploymentRolledOut(obj NamedObject) bool {
+	replicas, ok := getInt64(obj, Path{"spec", "replicas"})
+	if !ok {
+		replicas = 1
+	}
+	statusReplicas, _ := getInt64(obj, Path{"status", "replicas"})
+	updatedReplicas, _ := getInt64(obj, Path{"status", "updatedReplicas"})
+	availableReplicas, _ := getInt64(obj, Path{"status", "availableReplicas"})
+
+	return updatedReplicas >= replicas &&
+		statusReplicas <= updatedReplicas &&
+		availableReplicas >= updatedReplicas
+}
+
+// isStatefulSetRolledOut returns true if all replicas of a StatefulSet are
+// ready and, for the RollingUpdate strategy, have been updated.
+// StatefulSets using the OnDelete strategy are considered rolled out once all
+// replicas are ready.
+func isStatefulSetRolledOut(obj NamedObject) bool {
+	replicas, ok := getInt64(obj, Path{"spec", "replicas"})
+	if !ok {
+		replicas = 1
+	}
+	readyReplicas, _ := getInt64(obj, Path{"status", "readyReplicas"})
+	if readyReplicas < replicas {
+		return false
+	}
+
+	if strategy, _ := obj.GetString(Path{"spec", "updateStrategy", "type"}); strategy == "OnDelete" {
+		return true
+	}
+
+	// With a partition, only replicas with an ordinal >= partition are updated
+	if partition, ok := getInt64(obj, Path{"spec", "updateStrategy", "rollingUpdate", "partition"}); ok {
+		updatedReplicas, _ := getInt64(obj, Path{"status", "updatedReplicas"})
+		return updatedReplicas >= replicas-partition
+	}
+
+	updateRevision, _ := obj.GetString(Path{"status", "updateRevision"})
+	currentRevision, _ := obj.GetString(Path{"status", "currentRevision"})
+	return updateRevision == currentRevision
+}
+
+// isDaemonSetRolledOut returns true if all scheduled pods of a DaemonSet have
+// been updated and are available.
+func isDaemonSetRolledOut(obj NamedObject) bool {
+	desired, _ := getInt64(obj, Path{"status", "desiredNumberScheduled"})
+	updated, _ := getInt64(obj, Path{"status", "updatedNumberScheduled"})
+	available, _ := getInt64(obj, Path{"status", "numberAvailable"})
+
+	return updated >= desired && available >= desired
+}
+
+// getInt64 returns the integer stored at a given path. Returns false if the
+// path does not exist or does not hold a number.
+func getInt64(obj NamedObject, path Path) (int64, bool) {
+	value, err := obj.Get(path)
+	if err != nil {
+		return 0, false
+	}
+
+	number, ok := toFloat64(value)
+	return int64(number), ok
+}
+
+// toFloat64 converts any numeric value to float64. Returns false if value is
+// not a number.
+func toFloat64(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,193 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWaitFor(t *testing.T) {
+	fakeClient := newTestDynamicClient()
+	client := Client{client: fakeClient}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	go func() {
+		handle := fakeClient.Resource(ResourceConfigMap).Namespace("default")
+		time.Sleep(50 * time.Millisecond)
+
+		obj := newTestConfigMap("test")
+		_, _ = handle.Create(ctx, obj, metav1.CreateOptions{})
+
+		obj.Object["data"] = map[string]interface{}{"state": "done"}
+		_, _ = handle.Update(ctx, obj, metav1.UpdateOptions{})
+	}()
+
+	obj, err := client.WaitFor(ResourceConfigMap, "test", "default", PathEquals(Path{"data", "state"}, "done"), ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "test", obj.GetName())
+
+	// Objects already matching are returned immediately
+	obj, err = client.WaitFor(ResourceConfigMap, "test", "default", PathEquals(Path{"data", "state"}, "done"), ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "test", obj.GetName())
+
+	// Predicate errors stop waiting
+	failed := ErrRolloutFailed{Object: "default/test"}
+	_, err = client.WaitFor(ResourceConfigMap, "test", "default", func(NamedObject) (bool, error) {
+		return false, failed
+	}, ctx)
+	assert.ErrorIs(t, err, failed)
+
+	waitCtx, waitCancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer waitCancel()
+	_, err = client.WaitFor(ResourceConfigMap, "test", "default", PathEquals(Path{"data", "state"}, "failed"), waitCtx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+// matches calls a predicate that is not expected to fail.
+func matches(t *testing.T, predicate WaitPredicate, obj NamedObject) bool {
+	matched, err := predicate(obj)
+	assert.NoError(t, err)
+	return matched
+}
+
+func TestPathEquals(t *testing.T) {
+	obj := NamedObject{
+		"spec": map[string]interface{}{
+			"replicas": int64(3),
+			"paused":   true,
+		},
+	}
+
+	assert.True(t, matches(t, PathEquals(Path{"spec", "replicas"}, 3), obj))
+	assert.True(t, matches(t, PathEquals(Path{"spec", "replicas"}, float64(3)), obj))
+	assert.False(t, matches(t, PathEquals(Path{"spec", "replicas"}, 2), obj))
+	assert.True(t, matches(t, PathEquals(Path{"spec", "paused"}, true), obj))
+	assert.False(t, matches(t, PathEquals(Path{"spec", "missing"}, true), obj))
+}
+
+func TestConditionPredicates(t *testing.T) {
+	job := NamedObject{
+		"kind": "Job",
+		"status": map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Complete", "status": "True"},
+				map[string]interface{}{"type": "Ready", "status": "False"},
+			},
+		},
+	}
+
+	assert.True(t, matches(t, JobSucceeded, job))
+	assert.True(t, matches(t, ConditionTrue("Complete"), job))
+	assert.False(t, matches(t, ConditionTrue("Ready"), job))
+	assert.False(t, matches(t, ConditionTrue("Failed"), job))
+}
+
+func TestRolloutComplete(t *testing.T) {
+	newObject := func(kind string, spec, status map[string]interface{}) NamedObject {
+		status["observedGeneration"] = int64(2)
+		return NamedObject{
+			"kind":     kind,
+			"metadata": map[string]interface{}{"generation": int64(2)},
+			"spec":     spec,
+			"status":   status,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		obj      NamedObject
+		expected bool
+	}{
+		{
+			name: "deployment complete",
+			obj: newObject("Deployment", map[string]interface{}{"replicas": int64(3)},
+				map[string]interface{}{"replicas": int64(3), "updatedReplicas": int64(3), "availableReplicas": int64(3)}),
+			expected: true,
+		},
+		{
+			name: "deployment with old replicas",
+			obj: newObject("Deployment", map[string]interface{}{"replicas": int64(3)},
+				map[string]interface{}{"replicas": int64(4), "updatedReplicas": int64(3), "availableReplicas": int64(3)}),
+			expected: false,
+		},
+		{
+			name: "deployment not available",
+			obj: newObject("Deployment", map[string]interface{}{"replicas": int64(3)},
+				map[string]interface{}{"replicas": int64(3), "updatedReplicas": int64(3), "availableReplicas": int64(2)}),
+			expected: false,
+		},
+		{
+			name: "statefulset complete",
+			obj: newObject("StatefulSet", map[string]interface{}{"replicas": int64(2)},
+				map[string]interface{}{"readyReplicas": int64(2), "currentRevision": "a", "updateRevision": "a"}),
+			expected: true,
+		},
+		{
+			name: "statefulset updating",
+			obj: newObject("StatefulSet", map[string]interface{}{"replicas": int64(2)},
+				map[string]interface{}{"readyReplicas": int64(2), "currentRevision": "a", "updateRevision": "b"}),
+			expected: false,
+		},
+		{
+			name: "statefulset partitioned",
+			obj: newObject("StatefulSet", map[string]interface{}{
+				"replicas": int64(3),
+				"updateStrategy": map[string]interface{}{
+					"type":          "RollingUpdate",
+					"rollingUpdate": map[string]interface{}{"partition": int64(2)},
+				},
+			}, map[string]interface{}{"readyReplicas": int64(3), "updatedReplicas": int64(1), "currentRevision": "a", "updateRevision": "b"}),
+			expected: true,
+		},
+		{
+			name: "daemonset complete",
+			obj: newObject("DaemonSet", map[string]interface{}{},
+				map[string]interface{}{"desiredNumberScheduled": int64(5), "updatedNumberScheduled": int64(5), "numberAvailable": int64(5)}),
+			expected: true,
+		},
+		{
+			name: "daemonset updating",
+			obj: newObject("DaemonSet", map[string]interface{}{},
+				map[string]interface{}{"desiredNumberScheduled": int64(5), "updatedNumberScheduled": int64(4), "numberAvailable": int64(5)}),
+			expected: false,
+		},
+		{
+			name: "unsupported kind",
+			obj: newObject("ConfigMap", map[string]interface{}{},
+				map[string]interface{}{}),
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, matches(t, RolloutComplete, test.obj))
+		})
+	}
+
+	// Specs not yet observed by the controller are never complete
+	outdated := newObject("DaemonSet", map[string]interface{}{}, map[string]interface{}{})
+	outdated["metadata"] = map[string]interface{}{"generation": int64(3)}
+	assert.False(t, matches(t, RolloutComplete, outdated))
+
+	// Deployments exceeding their progress deadline fail immediately
+	stuck := newObject("Deployment", map[string]interface{}{"replicas": int64(3)}, map[string]interface{}{
+		"replicas": int64(3), "updatedReplicas": int64(1), "availableReplicas": int64(2),
+		"conditions": []interface{}{
+			map[string]interface{}{
+				"type":    "Progressing",
+				"status":  "False",
+				"reason":  "ProgressDeadlineExceeded",
+				"message": "ReplicaSet \"app-1\" has timed out progressing.",
+			},
+		},
+	})
+	matched, err := RolloutComplete(stuck)
+	assert.False(t, matched)
+	assert.ErrorAs(t, err, new(ErrRolloutFailed))
+}
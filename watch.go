@@ -0,0 +1,305 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+)
+
+// WatchEvent is emitted by Client.Watch for every change on a watched
+// resource.
+type WatchEvent struct {
+	// Type is one of watch.Added, watch.Modified, watch.Deleted,
+	// watch.Bookmark or watch.Error.
+	Type watch.EventType
+
+	// Object holds the object affected by this event. For bookmark events only
+	// metadata.resourceVersion is set.
+	Object NamedObject
+
+	// Err is set if the object could not be converted to a NamedObject or if
+	// the watch had to be stopped. The latter is reported as watch.Error and is
+	// always the last event sent before the channel is closed.
+	Err error
+}
+
+// watchBackoff defines the delay between attempts to re-establish a dropped
+// watch connection.
+var watchBackoff = wait.Backoff{
+	Duration: 500 * time.Millisecond,
+	Factor:   2.0,
+	Jitter:   0.1,
+	Steps:    math.MaxInt32,
+	Cap:      30 * time.Second,
+}
+
+// resumableWatch holds the state required to restart a watch after the
+// connection has been dropped or the resourceVersion has expired.
+type resumableWatch struct {
+	resourceHandle dynamic.ResourceInterface
+	options        metav1.ListOptions
+	events         chan WatchEvent
+
+	// known holds all objects reported so far, so that a re-list can report
+	// objects deleted while no watch was active.
+	known map[string]NamedObject
+}
+
+// Watch starts watching a given resource and returns a channel of events
+// carrying NamedObjects. If namespace is left empty, all namespaces or a
+// global resource is watched. Both labelSelector and fieldSelector are
+// optional.
+// The watch starts with an added event for each existing object. Dropped
+// connections are resumed from the last seen resourceVersion. If that version
+// has expired (410 Gone), the resource is listed again. In that case all
+// existing objects are reported as modified (or added if unknown) and objects
+// that disappeared in the meantime are reported as deleted. Temporary errors
+// reported by the server are retried with a backoff.
+// The channel is closed when ctx is done or the watch cannot be resumed.
+func (k8s *Client) Watch(resource schema.GroupVersionResource, namespace, labelSelector, fieldSelector string, ctx context.Context) (<-chan WatchEvent, error) {
+	w := &resumableWatch{
+		resourceHandle: k8s.getResourceHandle(resource, namespace),
+		options: metav1.ListOptions{
+			LabelSelector:       labelSelector,
+			FieldSelector:       fieldSelector,
+			AllowWatchBookmarks: true,
+		},
+		events: make(chan WatchEvent),
+		known:  make(map[string]NamedObject),
+	}
+
+	watcher, err := w.resourceHandle.Watch(ctx, w.options)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to watch %s", resource.String())
+	}
+
+	go w.run(watcher, ctx)
+	return w.events, nil
+}
+
+// run forwards events from watcher until the context is done or the watch
+// cannot be resumed.
+func (w *resumableWatch) run(watcher watch.Interface, ctx context.Context) {
+	defer close(w.events)
+	backoff := watchBackoff
+
+	for {
+		resourceVersion := w.options.ResourceVersion
+		err := w.forward(watcher, ctx)
+		watcher.Stop()
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		// Errors reported by the server are retried with a backoff, as
+		// restarting the watch itself will most likely succeed.
+		expired := isResourceVersionExpired(err)
+		if err != nil && !expired {
+			if !isRetryableWatchError(err) {
+				w.send(WatchEvent{Type: watch.Error, Err: errors.Wrapf(err, "watch failed at resourceVersion %s", w.options.ResourceVersion)}, ctx)
+				return
+			}
+
+			// Only back off further if the watch did not make any progress
+			if w.options.ResourceVersion != resourceVersion {
+				backoff = watchBackoff
+			}
+			if sleepWithBackoff(&backoff, ctx) != nil {
+				return
+			}
+		}
+
+		if watcher, err = w.restart(expired, ctx); err != nil {
+			w.send(WatchEvent{Type: watch.Error, Err: err}, ctx)
+			return
+		}
+	}
+}
+
+// restart re-establishes the watch from the last known resourceVersion. If
+// the resourceVersion has expired, the resource is listed again first.
+// Temporary errors are retried with a backoff.
+func (w *resumableWatch) restart(expired bool, ctx context.Context) (watch.Interface, error) {
+	backoff := watchBackoff
+
+	for {
+		var err error
+		if expired {
+			if err = w.relist(ctx); err == nil {
+				expired = false
+			}
+		}
+
+		if err == nil {
+			var watcher watch.Interface
+			watcher, err = w.resourceHandle.Watch(ctx, w.options)
+			switch {
+			case err == nil:
+				return watcher, nil
+
+			case isResourceVersionExpired(err):
+				expired = true
+				continue
+			}
+			err = errors.Wrapf(err, "failed to resume watch at resourceVersion %s", w.options.ResourceVersion)
+		}
+
+		if !isRetryableWatchError(err) {
+			return nil, err
+		}
+
+		if err := sleepWithBackoff(&backoff, ctx); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// forward converts and sends all events from watcher until the result
+// channel is closed or the context is done. Returns the error reported by
+// the server if the watch stopped because of an error event.
+func (w *resumableWatch) forward(watcher watch.Interface, ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return nil
+			}
+
+			if event.Type == watch.Error {
+				return apierrors.FromObject(event.Object)
+			}
+
+			rawObject, ok := event.Object.(*unstructured.Unstructured)
+			if !ok {
+				continue
+			}
+			w.options.ResourceVersion = rawObject.GetResourceVersion()
+
+			if event.Type == watch.Bookmark {
+				// Bookmarks do not carry a name, so we skip validation
+				w.send(WatchEvent{Type: event.Type, Object: NamedObject(rawObject.Object)}, ctx)
+				continue
+			}
+
+			obj, err := NamedObjectFromUnstructured(*rawObject)
+			w.track(event.Type, obj)
+			w.send(WatchEvent{Type: event.Type, Object: obj, Err: err}, ctx)
+		}
+	}
+}
+
+// relist lists all objects matching the watch and reports changes compared
+// to the objects seen so far. The resourceVersion of the list is used to
+// resume the watch.
+func (w *resumableWatch) relist(ctx context.Context) error {
+	list, err := w.resourceHandle.List(ctx, metav1.ListOptions{
+		LabelSelector: w.options.LabelSelector,
+		FieldSelector: w.options.FieldSelector,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to re-list after expired watch")
+	}
+
+	stale := w.known
+	w.known = make(map[string]NamedObject, len(list.Items))
+
+	for _, rawObject := range list.Items {
+		obj, parseErr := NamedObjectFromUnstructured(rawObject)
+		key := watchKey(obj)
+
+		eventType := watch.Added
+		if _, exists := stale[key]; exists {
+			eventType = watch.Modified
+			delete(stale, key)
+		}
+
+		w.known[key] = obj
+		if !w.send(WatchEvent{Type: eventType, Object: obj, Err: parseErr}, ctx) {
+			return ctx.Err()
+		}
+	}
+
+	for _, obj := range stale {
+		if !w.send(WatchEvent{Type: watch.Deleted, Object: obj}, ctx) {
+			return ctx.Err()
+		}
+	}
+
+	w.options.ResourceVersion = list.GetResourceVersion()
+	return nil
+}
+
+// track updates the list of known objects for a given event.
+func (w *resumableWatch) track(eventType watch.EventType, obj NamedObject) {
+	switch eventType {
+	case watch.Added, watch.Modified:
+		w.known[watchKey(obj)] = obj
+	case watch.Deleted:
+		delete(w.known, watchKey(obj))
+	}
+}
+
+// send passes an event to the consumer. Returns false if the context was
+// done before the event could be delivered.
+func (w *resumableWatch) send(event WatchEvent, ctx context.Context) bool {
+	select {
+	case w.events <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// sleepWithBackoff waits for the next step of a given backoff. Returns the
+// context's error if it was done before.
+func sleepWithBackoff(backoff *wait.Backoff, ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(backoff.Step()):
+		return nil
+	}
+}
+
+// watchKey returns a key uniquely identifying an object of a watched resource.
+func watchKey(obj NamedObject) string {
+	return fmt.Sprintf("%s/%s", obj.GetNamespace(), obj.GetName())
+}
+
+// isResourceVersionExpired returns true if err denotes that a watch cannot be
+// resumed from the requested resourceVersion.
+func isResourceVersionExpired(err error) bool {
+	return apierrors.IsGone(err) || apierrors.IsResourceExpired(err)
+}
+
+// isRetryableWatchError returns true if err is a temporary error, i.e. a
+// connection error or a server side error that might go away on retry.
+func isRetryableWatchError(err error) bool {
+	switch {
+	case apierrors.IsTooManyRequests(err),
+		apierrors.IsServerTimeout(err),
+		apierrors.IsTimeout(err),
+		apierrors.IsInternalError(err),
+		apierrors.IsServiceUnavailable(err),
+		apierrors.IsUnexpectedServerError(err):
+		return true
+	}
+
+	// Any other API status (e.g. forbidden) will not change on retry
+	var status apierrors.APIStatus
+	return !errors.As(err, &status)
+}
@@ -0,0 +1,175 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func newTestConfigMap(name string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": "default",
+			},
+		},
+	}
+}
+
+func newTestDynamicClient(objects ...runtime.Object) *dynamicfake.FakeDynamicClient {
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			ResourceConfigMap: "ConfigMapList",
+		}, objects...)
+}
+
+func TestWatch(t *testing.T) {
+	client := Client{client: newTestDynamicClient()}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	events, err := client.Watch(ResourceConfigMap, "default", "", "", ctx)
+	assert.NoError(t, err)
+
+	_, err = client.client.Resource(ResourceConfigMap).Namespace("default").Create(ctx, newTestConfigMap("test"), metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	event := <-events
+	assert.Equal(t, watch.Added, event.Type)
+	assert.NoError(t, event.Err)
+	assert.Equal(t, "test", event.Object.GetName())
+
+	err = client.client.Resource(ResourceConfigMap).Namespace("default").Delete(ctx, "test", metav1.DeleteOptions{})
+	assert.NoError(t, err)
+
+	event = <-events
+	assert.Equal(t, watch.Deleted, event.Type)
+	assert.Equal(t, "test", event.Object.GetName())
+
+	cancel()
+	_, open := <-events
+	assert.False(t, open)
+}
+
+func TestWatchRelist(t *testing.T) {
+	fakeClient := newTestDynamicClient(newTestConfigMap("kept"), newTestConfigMap("added"))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	w := &resumableWatch{
+		resourceHandle: fakeClient.Resource(ResourceConfigMap).Namespace("default"),
+		events:         make(chan WatchEvent, 3),
+		known: map[string]NamedObject{
+			"default/kept":    NamedObject(newTestConfigMap("kept").Object),
+			"default/deleted": NamedObject(newTestConfigMap("deleted").Object),
+		},
+	}
+
+	assert.NoError(t, w.relist(ctx))
+	close(w.events)
+
+	types := map[string]watch.EventType{}
+	for event := range w.events {
+		types[event.Object.GetName()] = event.Type
+	}
+
+	assert.Equal(t, map[string]watch.EventType{
+		"kept":    watch.Modified,
+		"added":   watch.Added,
+		"deleted": watch.Deleted,
+	}, types)
+	assert.Len(t, w.known, 2)
+}
+
+func TestWatchRelistRetry(t *testing.T) {
+	defer func(backoff wait.Backoff) { watchBackoff = backoff }(watchBackoff)
+	watchBackoff.Duration = 10 * time.Millisecond
+
+	fakeClient := newTestDynamicClient(newTestConfigMap("test"))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	failures := 0
+	fakeClient.PrependReactor("list", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if failures < 2 {
+			failures++
+			return true, nil, apierrors.NewServiceUnavailable("try again")
+		}
+		return false, nil, nil
+	})
+
+	w := &resumableWatch{
+		resourceHandle: fakeClient.Resource(ResourceConfigMap).Namespace("default"),
+		events:         make(chan WatchEvent),
+		known:          make(map[string]NamedObject),
+	}
+
+	watcher := watch.NewFake()
+	go w.run(watcher, ctx)
+	watcher.Error(&apierrors.NewGone("expired").ErrStatus)
+
+	event := <-w.events
+	assert.Equal(t, watch.Added, event.Type)
+	assert.NoError(t, event.Err)
+	assert.Equal(t, "test", event.Object.GetName())
+	assert.Equal(t, 2, failures)
+
+	cancel()
+	for range w.events {
+	}
+}
+
+func TestWatchErrorEvent(t *testing.T) {
+	defer func(backoff wait.Backoff) { watchBackoff = backoff }(watchBackoff)
+	watchBackoff.Duration = 50 * time.Millisecond
+
+	fakeClient := newTestDynamicClient()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	restarted := make(chan *watch.FakeWatcher, 1)
+	fakeClient.PrependWatchReactor("configmaps", func(action k8stesting.Action) (bool, watch.Interface, error) {
+		watcher := watch.NewFake()
+		restarted <- watcher
+		return true, watcher, nil
+	})
+
+	w := &resumableWatch{
+		resourceHandle: fakeClient.Resource(ResourceConfigMap).Namespace("default"),
+		events:         make(chan WatchEvent),
+		known:          make(map[string]NamedObject),
+	}
+
+	watcher := watch.NewFake()
+	go w.run(watcher, ctx)
+
+	// Temporary errors restart the watch after a backoff
+	start := time.Now()
+	watcher.Error(&apierrors.NewInternalError(assert.AnError).ErrStatus)
+	watcher = <-restarted
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+
+	// Other errors stop the watch
+	watcher.Error(&apierrors.NewForbidden(ResourceConfigMap.GroupResource(), "", assert.AnError).ErrStatus)
+
+	event := <-w.events
+	assert.Equal(t, watch.Error, event.Type)
+	assert.True(t, apierrors.IsForbidden(event.Err))
+
+	_, open := <-w.events
+	assert.False(t, open)
+}